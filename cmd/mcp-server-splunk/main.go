@@ -8,7 +8,9 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/jkosik/mcp-server-splunk/internal/splunk"
 
@@ -16,6 +18,9 @@ import (
 	"github.com/mark3labs/mcp-go/server"
 )
 
+// resultBudget bounds every tool result, see SPLUNK_RESULT_MAX_BYTES, SPLUNK_RESULT_MAX_TOKENS and SPLUNK_RESULT_MAX_FIELD_LENGTH
+var resultBudget = splunk.ResultBudget{MaxBytes: splunk.DefaultResultMaxBytes, MaxFieldLength: splunk.DefaultResultMaxFieldLength}
+
 func main() {
 	// Parse transport flag
 	transport := flag.String("transport", "stdio", "Transport type: stdio or sse")
@@ -42,6 +47,42 @@ func main() {
 	// Create Splunk client
 	client := splunk.NewClient(baseURL, authToken)
 
+	// Result size budget, in bytes or in approximate tokens
+	if v, err := strconv.Atoi(os.Getenv("SPLUNK_RESULT_MAX_TOKENS")); err == nil && v > 0 {
+		resultBudget.MaxBytes = v * splunk.BytesPerToken
+	}
+	if v, err := strconv.Atoi(os.Getenv("SPLUNK_RESULT_MAX_BYTES")); err == nil && v > 0 {
+		resultBudget.MaxBytes = v
+	}
+	if v, err := strconv.Atoi(os.Getenv("SPLUNK_RESULT_MAX_FIELD_LENGTH")); err == nil && v > 0 {
+		resultBudget.MaxFieldLength = v
+	}
+
+	// Write tools are only registered when write mode is explicitly enabled. Every write and dry run is audited.
+	writeMode := os.Getenv("SPLUNK_WRITE_MODE") == "true" || os.Getenv("SPLUNK_WRITE_MODE") == "1"
+	if writeMode {
+		audit, err := splunk.NewAuditLog(os.Getenv("SPLUNK_AUDIT_LOG"))
+		if err != nil {
+			log.Fatalf("Failed to open audit log: %v", err)
+		}
+		client.Audit = audit
+	}
+
+	// Get current working directory to determine path to resources
+	cwd, err := os.Getwd()
+	if err != nil {
+		log.Fatalf("Failed to get working directory: %v", err)
+	}
+
+	// If running from cmd/mcp-server-splunk, resources are two levels up
+	var resourcePath string
+	if strings.HasSuffix(cwd, "cmd/mcp-server-splunk") {
+		resourcePath = filepath.Join("..", "..", "resources", "data-dictionary.csv")
+	} else {
+		// Assume running from project root
+		resourcePath = filepath.Join("resources", "data-dictionary.csv")
+	}
+
 	//////////////////////
 	// REGISTER ALL PROMPTS //
 	//////////////////////
@@ -54,6 +95,15 @@ func main() {
 		mcp.WithDescription("List Splunk saved searches (paginated by count and offset arguments)."),
 		mcp.WithNumber("count", mcp.Description("Number of results to return (default 10)")),
 		mcp.WithNumber("offset", mcp.Description("Offset for pagination (default 0)")),
+		mcp.WithString("name", mcp.Description("Case-insensitive substring of the saved search name (optional)")),
+		mcp.WithString("search_text", mcp.Description("Substring of the search SPL (optional)")),
+		mcp.WithString("app", mcp.Description("Filter by the app the object belongs to (optional)")),
+		mcp.WithString("owner", mcp.Description("Filter by the object owner (optional)")),
+		mcp.WithBoolean("disabled", mcp.Description("Only return disabled (true) or enabled (false) saved searches (optional)")),
+		mcp.WithBoolean("scheduled", mcp.Description("Only return scheduled (true) or unscheduled (false) saved searches (optional)")),
+		mcp.WithString("sort_key", mcp.Description("Field to sort by, e.g. name (optional)")),
+		mcp.WithString("sort_dir", mcp.Enum("asc", "desc"), mcp.Description("Sort direction (default asc)")),
+		mcp.WithString("fields", mcp.Description("Comma-separated fields to return, including detail fields of get_splunk_saved_search, e.g. \"name,cron_schedule,next_scheduled_time\" (optional)")),
 	)
 
 	s.AddTool(splunkTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -70,8 +120,21 @@ func main() {
 			offset = int(v)
 		}
 
+		filter := listFilterFromArguments(request.Params.Arguments)
+
 		// Run the Splunk client and get the Splunk API response
-		searches, total, err := client.GetSavedSearches(ctx, count, offset)
+		var searches interface{}
+		var total int
+		var err error
+		if fields, ok := request.Params.Arguments["fields"].(string); ok && fields != "" {
+			var details []splunk.SavedSearchDetail
+			details, total, err = client.GetSavedSearchDetails(ctx, count, offset, filter)
+			if err == nil {
+				searches, err = splunk.SelectFields(details, splitFields(fields))
+			}
+		} else {
+			searches, total, err = client.GetSavedSearches(ctx, count, offset, filter)
+		}
 		if err != nil {
 			return mcp.NewToolResultError("failed to get saved searches: " + err.Error()), nil
 		}
@@ -84,11 +147,27 @@ func main() {
 			"offset":   offset,
 			"total":    total,
 		}
-		data, err := json.Marshal(result)
+		return jsonResult(note, result)
+	})
+
+	savedSearchTool := mcp.NewTool("get_splunk_saved_search",
+		mcp.WithDescription("Get a single Splunk saved search with its schedule (cron, next run), dispatch time range, alert condition and threshold, suppression settings and alert action parameters (e.g. email recipients, webhook URLs)."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Saved search name")),
+		mcp.WithString("app", mcp.Description("App the saved search belongs to, when the name exists in several apps (optional)")),
+	)
+
+	s.AddTool(savedSearchTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.Params.Arguments["name"].(string)
+		if name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+		app, _ := request.Params.Arguments["app"].(string)
+
+		search, err := client.GetSavedSearch(ctx, name, app)
 		if err != nil {
-			return mcp.NewToolResultError("failed to marshal results: " + err.Error()), nil
+			return mcp.NewToolResultError("failed to get saved search: " + err.Error()), nil
 		}
-		return mcp.NewToolResultText(note + "\n\n" + string(data)), nil
+		return jsonResult("", search)
 	})
 
 	//////////////////////
@@ -133,21 +212,161 @@ func main() {
 			"offset": offset,
 			"total":  total,
 		}
-		data, err := json.Marshal(result)
+		return jsonResult(note, result)
+	})
+
+	//////////////////////
+	// TRIGGERED ALERT DETAILS (instances from /services/alerts/fired_alerts, triggering job results and alert action outcomes) //
+	//////////////////////
+	triggeredTool := mcp.NewTool("list_splunk_triggered_alerts",
+		mcp.WithDescription("List triggered alert instances, newest first, with severity, trigger time, expiration, SID and result count of the triggering job (paginated by count and offset arguments)."),
+		mcp.WithNumber("count", mcp.Description("Number of results to return (default 10)")),
+		mcp.WithNumber("offset", mcp.Description("Offset for pagination (default 0)")),
+		mcp.WithString("ss_name", mcp.Description("Saved search name to filter triggered alerts (optional)")),
+	)
+
+	s.AddTool(triggeredTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		count := 10
+		offset := 0
+		// Each instance costs a job lookup for its result count, so the limit is lower than for plain listings
+		if v, ok := request.Params.Arguments["count"].(float64); ok {
+			count = int(v)
+			if count > 50 {
+				count = 50
+			}
+		}
+		if v, ok := request.Params.Arguments["offset"].(float64); ok {
+			offset = int(v)
+		}
+		ssName, _ := request.Params.Arguments["ss_name"].(string)
+
+		alerts, total, err := client.GetTriggeredAlerts(ctx, count, offset, ssName)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get triggered alerts: " + err.Error()), nil
+		}
+		note := fmt.Sprintf("Showing up to %d triggered alerts (as requested). Use 'offset' to paginate. Maximum per call is 50. Use get_splunk_job_results and get_splunk_alert_actions with the SID for details.", count)
+		result := map[string]interface{}{
+			"alerts": alerts,
+			"count":  count,
+			"offset": offset,
+			"total":  total,
+		}
+		return jsonResult(note, result)
+	})
+
+	jobResultsTool := mcp.NewTool("get_splunk_job_results",
+		mcp.WithDescription("Get the results of a search job by SID, e.g. the job that triggered an alert (paginated by count and offset arguments)."),
+		mcp.WithString("sid", mcp.Required(), mcp.Description("Search job ID")),
+		mcp.WithNumber("count", mcp.Description("Number of results to return (default 10)")),
+		mcp.WithNumber("offset", mcp.Description("Offset for pagination (default 0)")),
+	)
+
+	s.AddTool(jobResultsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sid, _ := request.Params.Arguments["sid"].(string)
+		if sid == "" {
+			return mcp.NewToolResultError("sid is required"), nil
+		}
+		count := 10
+		offset := 0
+		if v, ok := request.Params.Arguments["count"].(float64); ok {
+			count = int(v)
+			if count > 100 {
+				count = 100
+			}
+		}
+		if v, ok := request.Params.Arguments["offset"].(float64); ok {
+			offset = int(v)
+		}
+
+		results, total, err := client.GetJobResults(ctx, sid, count, offset)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get job results: " + err.Error()), nil
+		}
+		note := fmt.Sprintf("Showing up to %d results (as requested). Use 'offset' to paginate. Maximum per call is 100.", count)
+		result := map[string]interface{}{
+			"results": results,
+			"count":   count,
+			"offset":  offset,
+			"total":   total,
+		}
+		return jsonResult(note, result)
+	})
+
+	alertActionsTool := mcp.NewTool("get_splunk_alert_actions",
+		mcp.WithDescription("Get the outcome of the alert actions (email, webhook, ...) run for a triggered alert, from index=_internal sourcetype=splunkd component=sendmodalert."),
+		mcp.WithString("sid", mcp.Required(), mcp.Description("SID of the job that triggered the alert")),
+		mcp.WithString("earliest", mcp.Description("How far back to look for the action invocation (default \"-24h\")")),
+	)
+
+	s.AddTool(alertActionsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		sid, _ := request.Params.Arguments["sid"].(string)
+		if sid == "" {
+			return mcp.NewToolResultError("sid is required"), nil
+		}
+		earliest := "-24h"
+		if v, ok := request.Params.Arguments["earliest"].(string); ok && v != "" {
+			earliest = v
+		}
+
+		actions, err := client.GetAlertActionStatus(ctx, sid, earliest)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get alert actions: " + err.Error()), nil
+		}
+		result := map[string]interface{}{
+			"sid":     sid,
+			"actions": actions,
+		}
+		return jsonResult("", result)
+	})
+
+	//////////////////////
+	// ALERT NOISE //
+	//////////////////////
+	alertNoiseTool := mcp.NewTool("get_splunk_alert_noise",
+		mcp.WithDescription("Rank the noisiest alerts and the ones that went silent, from index=_audit action=alert_fired. Per alert: fires, rate per day, hourly burstiness, hour-of-day distribution and change versus the previous window of the same length."),
+		mcp.WithNumber("days", mcp.Description("Window length in days, compared with the preceding window of the same length (default 7)")),
+		mcp.WithNumber("count", mcp.Description("Number of noisiest and silent alerts to return (default 10)")),
+		mcp.WithString("ss_name", mcp.Description("Search name pattern to filter alerts (default \"*\")")),
+	)
+
+	s.AddTool(alertNoiseTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		days := 7
+		count := 10
+		ssName := "*"
+		if v, ok := request.Params.Arguments["days"].(float64); ok && v >= 1 {
+			days = int(v)
+			if days > 90 {
+				days = 90
+			}
+		}
+		if v, ok := request.Params.Arguments["count"].(float64); ok {
+			count = int(v)
+			if count > 100 {
+				count = 100
+			}
+		}
+		if v, ok := request.Params.Arguments["ss_name"].(string); ok && v != "" {
+			ssName = v
+		}
+
+		report, err := client.GetAlertNoise(ctx, days, count, ssName)
 		if err != nil {
-			return mcp.NewToolResultError("failed to marshal results: " + err.Error()), nil
+			return mcp.NewToolResultError("failed to get alert noise: " + err.Error()), nil
 		}
-		return mcp.NewToolResultText(note + "\n\n" + string(data)), nil
+		note := fmt.Sprintf("Alert firing over the last %d days compared with the %d days before. Maximum window is 90 days, maximum count is 100.", days, days)
+		return jsonResult(note, report)
 	})
 
 	//////////////////////
-	// ALERTS (With actions, filterable by title. Using SPL in API and the entire json is returned - mimicking pagination in GetAlerts.) //
+	// ALERTS (With actions, filterable by title. Filtering and pagination are done by the Splunk REST API.) //
 	//////////////////////
 	alertsAllTool := mcp.NewTool("list_splunk_alerts",
 		mcp.WithDescription("List all Splunk alerts (saved searches with actions). Supports pagination and optional case-insensitive title filter."),
 		mcp.WithNumber("count", mcp.Description("Number of results to return (default 10)")),
 		mcp.WithNumber("offset", mcp.Description("Offset for pagination (default 0)")),
 		mcp.WithString("title", mcp.Description("Case-insensitive substring to filter alert titles (optional)")),
+		mcp.WithString("app", mcp.Description("Filter by the app the object belongs to (optional)")),
+		mcp.WithString("owner", mcp.Description("Filter by the object owner (optional)")),
 	)
 
 	s.AddTool(alertsAllTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -166,7 +385,10 @@ func main() {
 		if v, ok := request.Params.Arguments["title"].(string); ok {
 			title = v
 		}
-		alerts, total, err := client.GetAlerts(ctx, count, offset, title)
+		filter := splunk.ListFilter{Name: title}
+		filter.App, _ = request.Params.Arguments["app"].(string)
+		filter.Owner, _ = request.Params.Arguments["owner"].(string)
+		alerts, total, err := client.GetAlerts(ctx, count, offset, filter)
 		if err != nil {
 			return mcp.NewToolResultError("failed to get alerts: " + err.Error()), nil
 		}
@@ -177,11 +399,133 @@ func main() {
 			"offset": offset,
 			"total":  total,
 		}
-		data, err := json.Marshal(result)
+		return jsonResult(note, result)
+	})
+
+	//////////////////////
+	// ALERT SUPPRESSIONS //
+	//////////////////////
+	suppressionsTool := mcp.NewTool("list_splunk_alert_suppressions",
+		mcp.WithDescription("List alerts that are currently suppressed (throttled), with their suppression fields, period and remaining time. Suppression settings of every alert are also part of list_splunk_alerts."),
+		mcp.WithString("title", mcp.Description("Case-insensitive substring to filter alert titles (optional)")),
+		mcp.WithString("app", mcp.Description("Filter by the app the alert belongs to (optional)")),
+		mcp.WithBoolean("include_inactive", mcp.Description("Also list alerts with suppression configured but not active right now (default false)")),
+	)
+
+	s.AddTool(suppressionsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		var filter splunk.ListFilter
+		filter.Name, _ = request.Params.Arguments["title"].(string)
+		filter.App, _ = request.Params.Arguments["app"].(string)
+		includeInactive, _ := request.Params.Arguments["include_inactive"].(bool)
+
+		suppressions, err := client.GetActiveSuppressions(ctx, filter, includeInactive)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get alert suppressions: " + err.Error()), nil
+		}
+		result := map[string]interface{}{
+			"suppressions": suppressions,
+			"total":        len(suppressions),
+		}
+		return jsonResult("", result)
+	})
+
+	//////////////////////
+	// SCHEDULER HEALTH //
+	//////////////////////
+	schedulerTool := mcp.NewTool("get_splunk_scheduler_health",
+		mcp.WithDescription("Summarize scheduler activity (index=_internal sourcetype=scheduler) per saved search: run, success, skipped, deferred and failed counts, skip reasons, average runtime and last run. Use it to find alerts that are silently not running."),
+		mcp.WithNumber("count", mcp.Description("Maximum number of saved searches to return, worst first (default 50)")),
+		mcp.WithString("ss_name", mcp.Description("Saved search name pattern (default \"*\")")),
+		mcp.WithString("earliest", mcp.Description("Start of the time range (default \"-24h\")")),
+		mcp.WithString("latest", mcp.Description("End of the time range (default \"now\")")),
+		mcp.WithBoolean("problems_only", mcp.Description("Only return saved searches with skipped, deferred or failed runs (default false)")),
+	)
+
+	s.AddTool(schedulerTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		count := 50
+		ssName := "*"
+		earliest := "-24h"
+		latest := "now"
+		if v, ok := request.Params.Arguments["count"].(float64); ok {
+			count = int(v)
+			if count > 500 {
+				count = 500
+			}
+		}
+		if v, ok := request.Params.Arguments["ss_name"].(string); ok && v != "" {
+			ssName = v
+		}
+		if v, ok := request.Params.Arguments["earliest"].(string); ok && v != "" {
+			earliest = v
+		}
+		if v, ok := request.Params.Arguments["latest"].(string); ok && v != "" {
+			latest = v
+		}
+		problemsOnly, _ := request.Params.Arguments["problems_only"].(bool)
+
+		health, err := client.GetSchedulerHealth(ctx, count, ssName, earliest, latest, problemsOnly)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get scheduler health: " + err.Error()), nil
+		}
+		note := fmt.Sprintf("Showing up to %d saved searches between %s and %s, most skipped/deferred/failed first. Maximum per call is 500.", count, earliest, latest)
+		result := map[string]interface{}{
+			"searches": health,
+			"count":    count,
+		}
+		return jsonResult(note, result)
+	})
+
+	//////////////////////
+	// ALERT BACKTEST //
+	//////////////////////
+	backtestTool := mcp.NewTool("backtest_splunk_alert",
+		mcp.WithDescription("Estimate how often an alert would have fired over a past window by running its SPL once per scheduled run as async search jobs (at most 4 at a time and 200 slices per backtest). Pass a saved search name, or raw SPL with an interval and optional condition."),
+		mcp.WithString("name", mcp.Description("Saved search to backtest with its own schedule and alert condition (optional if search is set)")),
+		mcp.WithString("app", mcp.Description("App the saved search belongs to (optional)")),
+		mcp.WithString("search", mcp.Description("Raw SPL to backtest instead of a saved search (optional)")),
+		mcp.WithString("interval", mcp.Description("Time between runs, e.g. \"15m\" or \"1h\" (default: the saved search cron schedule, 1h for raw SPL); saved search runs look back over their dispatch.earliest_time window")),
+		mcp.WithNumber("days", mcp.Description("Number of past days to evaluate (default 1)")),
+		mcp.WithString("alert_type", mcp.Enum("number of events", "number of results", "always", "custom"), mcp.Description("Trigger type for raw SPL (default \"number of events\")")),
+		mcp.WithString("alert_comparator", mcp.Enum("greater than", "less than", "equal to", "not equal to", "rises by", "drops by"), mcp.Description("Comparator for raw SPL (default \"greater than\")")),
+		mcp.WithString("alert_threshold", mcp.Description("Threshold for raw SPL (default \"0\")")),
+		mcp.WithString("alert_condition", mcp.Description("Custom condition search for alert_type custom, e.g. \"count > 10\"")),
+	)
+
+	s.AddTool(backtestTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.Params.Arguments["name"].(string)
+		app, _ := request.Params.Arguments["app"].(string)
+		spl, _ := request.Params.Arguments["search"].(string)
+		interval, _ := request.Params.Arguments["interval"].(string)
+		days := 1
+		if v, ok := request.Params.Arguments["days"].(float64); ok && v >= 1 {
+			days = int(v)
+		}
+
+		var report *splunk.BacktestReport
+		var err error
+		switch {
+		case name != "":
+			report, err = client.BacktestSavedSearch(ctx, name, app, days, interval)
+		case spl != "":
+			var step time.Duration
+			step, err = splunk.ParseInterval(interval)
+			if err == nil {
+				var condition splunk.BacktestCondition
+				condition.Type, _ = request.Params.Arguments["alert_type"].(string)
+				condition.Comparator, _ = request.Params.Arguments["alert_comparator"].(string)
+				condition.Threshold, _ = request.Params.Arguments["alert_threshold"].(string)
+				condition.Custom, _ = request.Params.Arguments["alert_condition"].(string)
+				report, err = client.Backtest(ctx, spl, condition, days, step, 0)
+			}
+		default:
+			return mcp.NewToolResultError("name or search is required"), nil
+		}
 		if err != nil {
-			return mcp.NewToolResultError("failed to marshal results: " + err.Error()), nil
+			return mcp.NewToolResultError("failed to backtest: " + err.Error()), nil
 		}
-		return mcp.NewToolResultText(note + "\n\n" + string(data)), nil
+
+		note := fmt.Sprintf("Would have triggered in %d of %d runs (%d failed) over the last %d days. Examples are shown for the first triggered slices only.", report.Triggered, report.Runs, report.Failed, days)
+		return jsonResult(note, report)
 	})
 
 	//////////////////////
@@ -191,6 +535,12 @@ func main() {
 		mcp.WithDescription("List Splunk indexes (paginated by count and offset arguments)"),
 		mcp.WithNumber("count", mcp.Description("Number of results to return (default 10)")),
 		mcp.WithNumber("offset", mcp.Description("Offset for pagination (default 0)")),
+		mcp.WithString("name", mcp.Description("Case-insensitive substring of the index name (optional)")),
+		mcp.WithBoolean("disabled", mcp.Description("Only return disabled (true) or enabled (false) indexes (optional)")),
+		mcp.WithString("datatype", mcp.Enum("event", "metric", "all"), mcp.Description("Index type to list (default event, as in Splunk)")),
+		mcp.WithString("sort_key", mcp.Description("Field to sort by, e.g. name (optional)")),
+		mcp.WithString("sort_dir", mcp.Enum("asc", "desc"), mcp.Description("Sort direction (default asc)")),
+		mcp.WithString("fields", mcp.Description("Comma-separated fields to return, including detail fields of get_splunk_index, e.g. \"name,current_db_size_mb,retention_days\" (optional)")),
 	)
 
 	s.AddTool(indexesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
@@ -206,7 +556,20 @@ func main() {
 			offset = int(v)
 		}
 
-		indexes, total, err := client.GetIndexes(ctx, count, offset)
+		filter := listFilterFromArguments(request.Params.Arguments)
+
+		var indexes interface{}
+		var total int
+		var err error
+		if fields, ok := request.Params.Arguments["fields"].(string); ok && fields != "" {
+			var details []splunk.IndexDetail
+			details, total, err = client.GetIndexDetails(ctx, count, offset, filter)
+			if err == nil {
+				indexes, err = splunk.SelectFields(details, splitFields(fields))
+			}
+		} else {
+			indexes, total, err = client.GetIndexes(ctx, count, offset, filter)
+		}
 		if err != nil {
 			return mcp.NewToolResultError("failed to get indexes: " + err.Error()), nil
 		}
@@ -218,97 +581,997 @@ func main() {
 			"offset":  offset,
 			"total":   total,
 		}
-		data, err := json.Marshal(result)
+		return jsonResult(note, result)
+	})
+
+	indexTool := mcp.NewTool("get_splunk_index",
+		mcp.WithDescription("Get a single Splunk index with its size (current and maximum MB), total event count, time span (min/max time), retention (frozenTimePeriodInSecs), data type (event or metric), home/cold/thawed paths and replication factor."),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Index name")),
+	)
+
+	s.AddTool(indexTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		name, _ := request.Params.Arguments["name"].(string)
+		if name == "" {
+			return mcp.NewToolResultError("name is required"), nil
+		}
+
+		index, err := client.GetIndex(ctx, name)
 		if err != nil {
-			return mcp.NewToolResultError("failed to marshal results: " + err.Error()), nil
+			return mcp.NewToolResultError("failed to get index: " + err.Error()), nil
 		}
-		return mcp.NewToolResultText(note + "\n\n" + string(data)), nil
+		return jsonResult("", index)
 	})
 
-	//////////////////////
-	// MACROS //
-	//////////////////////
-	macrosTool := mcp.NewTool("list_splunk_macros",
-		mcp.WithDescription("List Splunk macros (paginated by count and offset arguments)."),
-		mcp.WithNumber("count", mcp.Description("Number of results to return (default 10)")),
-		mcp.WithNumber("offset", mcp.Description("Offset for pagination (default 0)")),
+	indexContentsTool := mcp.NewTool("get_splunk_index_contents",
+		mcp.WithDescription("Summarize what an index holds: its sourcetypes, hosts and sources with event counts and first/last seen times over a time range (via tstats)."),
+		mcp.WithString("index", mcp.Required(), mcp.Description("Index name")),
+		mcp.WithString("earliest", mcp.Description("Start of the time range (default \"-24h\")")),
+		mcp.WithString("latest", mcp.Description("End of the time range (default \"now\")")),
+		mcp.WithNumber("count", mcp.Description("Maximum number of sourcetypes, hosts and sources each, most events first (default 50)")),
 	)
 
-	s.AddTool(macrosTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
-		count := 10
-		offset := 0
+	s.AddTool(indexContentsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, _ := request.Params.Arguments["index"].(string)
+		if index == "" {
+			return mcp.NewToolResultError("index is required"), nil
+		}
+		count := 50
+		earliest := "-24h"
+		latest := "now"
 		if v, ok := request.Params.Arguments["count"].(float64); ok {
 			count = int(v)
-			if count > 100 {
-				count = 100
+			if count > 500 {
+				count = 500
 			}
 		}
-		if v, ok := request.Params.Arguments["offset"].(float64); ok {
-			offset = int(v)
+		if v, ok := request.Params.Arguments["earliest"].(string); ok && v != "" {
+			earliest = v
 		}
-
-		macros, total, err := client.GetMacros(ctx, count, offset)
-		if err != nil {
-			return mcp.NewToolResultError("failed to get macros: " + err.Error()), nil
+		if v, ok := request.Params.Arguments["latest"].(string); ok && v != "" {
+			latest = v
 		}
 
-		note := fmt.Sprintf("Showing up to %d macros (as requested). Use 'offset' to paginate. Maximum per call is 100.", count)
-		result := map[string]interface{}{
-			"macros": macros,
-			"count":  count,
-			"offset": offset,
-			"total":  total,
-		}
-		data, err := json.Marshal(result)
+		contents, err := client.GetIndexContents(ctx, index, earliest, latest, count)
 		if err != nil {
-			return mcp.NewToolResultError("failed to marshal results: " + err.Error()), nil
+			return mcp.NewToolResultError("failed to get index contents: " + err.Error()), nil
 		}
-		return mcp.NewToolResultText(note + "\n\n" + string(data)), nil
+		note := fmt.Sprintf("Showing up to %d sourcetypes, hosts and sources of index %s between %s and %s, most events first. Maximum per call is 500.", count, index, earliest, latest)
+		return jsonResult(note, contents)
 	})
 
 	//////////////////////
-	// REGISTER ALL RESOURCES //
+	// DATA FRESHNESS //
 	//////////////////////
-	// Get current working directory to determine path to resources
-	cwd, err := os.Getwd()
-	if err != nil {
-		log.Fatalf("Failed to get working directory: %v", err)
-	}
+	freshnessTool := mcp.NewTool("get_splunk_data_freshness",
+		mcp.WithDescription("Detect data sources that stopped sending: last event time and indexing lag (_indextime - _time) percentiles per index/sourcetype via tstats, flagging sources whose last event is older than max_age or the expected_freshness from the data dictionary. Stale sources first."),
+		mcp.WithString("index", mcp.Description("Index name or pattern (default \"*\")")),
+		mcp.WithString("earliest", mcp.Description("How far back to look for events (default \"-24h\"); sources silent for the whole range only show up when listed in the data dictionary")),
+		mcp.WithString("max_age", mcp.Description("Default staleness threshold, e.g. \"15m\" or \"4h\" (default \"1h\")")),
+		mcp.WithBoolean("use_data_dictionary", mcp.Description("Use expected_freshness per index/sourcetype from the data dictionary resource, falling back to max_age when it is missing (default true)")),
+		mcp.WithBoolean("stale_only", mcp.Description("Only return stale sources (default false)")),
+		mcp.WithNumber("count", mcp.Description("Maximum number of sources to return (default 100)")),
+	)
 
-	// If running from cmd/mcp-server-splunk, resources are two levels up
-	var resourcePath string
-	if strings.HasSuffix(cwd, "cmd/mcp-server-splunk") {
-		resourcePath = filepath.Join("..", "..", "resources", "data-dictionary.csv")
-	} else {
-		// Assume running from project root
-		resourcePath = filepath.Join("resources", "data-dictionary.csv")
-	}
+	s.AddTool(freshnessTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index := "*"
+		earliest := "-24h"
+		maxAge := time.Hour
+		count := 100
+		if v, ok := request.Params.Arguments["index"].(string); ok && v != "" {
+			index = v
+		}
+		if v, ok := request.Params.Arguments["earliest"].(string); ok && v != "" {
+			earliest = v
+		}
+		if v, ok := request.Params.Arguments["max_age"].(string); ok && v != "" {
+			d, err := splunk.ParseInterval(v)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			maxAge = d
+		}
+		if v, ok := request.Params.Arguments["count"].(float64); ok {
+			count = int(v)
+			if count > 500 {
+				count = 500
+			}
+		}
+		staleOnly, _ := request.Params.Arguments["stale_only"].(bool)
 
-	// Register data dictionary resource
-	dataDictResource := mcp.NewResource(
-		"docs://data-dictionary",
-		"Data Dictionary",
-		mcp.WithResourceDescription("Splunk Data Dictionary containing information about data sources, criticality, indexes, sourcetypes, contact persons, and their metadata"),
-		mcp.WithMIMEType("text/csv"),
-	)
+		var expected map[string]time.Duration
+		dictionaryNote := ""
+		if v, ok := request.Params.Arguments["use_data_dictionary"].(bool); !ok || v {
+			// without a data dictionary every source is checked against max_age
+			if f, err := os.Open(resourcePath); err != nil {
+				dictionaryNote = " Data dictionary not available (" + err.Error() + "), using max_age for all sources."
+			} else {
+				expected, err = splunk.ParseFreshnessThresholds(f)
+				f.Close()
+				if err != nil {
+					return mcp.NewToolResultError(err.Error()), nil
+				}
+			}
+		}
 
-	s.AddResource(dataDictResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
-		content, err := os.ReadFile(resourcePath)
+		freshness, err := client.GetDataFreshness(ctx, index, earliest, maxAge, expected, staleOnly, count)
 		if err != nil {
-			return nil, fmt.Errorf("failed to read data dictionary from %s: %v", resourcePath, err)
+			return mcp.NewToolResultError("failed to get data freshness: " + err.Error()), nil
 		}
-
-		return []mcp.ResourceContents{
-			mcp.TextResourceContents{
-				URI:      "docs://data-dictionary",
-				MIMEType: "text/csv",
-				Text:     string(content),
-			},
-		}, nil
+		stale := 0
+		for _, f := range freshness {
+			if f.Stale {
+				stale++
+			}
+		}
+		note := fmt.Sprintf("%d of %d sources shown are stale (default max age %s, %d thresholds from the data dictionary). Lag percentiles are computed from the newest 100000 events of the range. Maximum per call is 500.", stale, len(freshness), maxAge, len(expected)) + dictionaryNote
+		result := map[string]interface{}{
+			"sources": freshness,
+			"count":   count,
+		}
+		return jsonResult(note, result)
 	})
 
-	// Start the server
-	if *transport == "sse" {
+	//////////////////////
+	// LICENSE USAGE //
+	//////////////////////
+	licenseTool := mcp.NewTool("get_splunk_license_usage",
+		mcp.WithDescription("Report license usage from license_usage.log: daily GB over the last days, the top indexes, sourcetypes, hosts, sources or pools with their trend versus the previous period, plus license pool quotas and licenser warnings/violations."),
+		mcp.WithString("split_by", mcp.Enum("index", "sourcetype", "host", "source", "pool"), mcp.Description("Field to rank ingest by (default \"index\")")),
+		mcp.WithNumber("days", mcp.Description("Number of whole past days to report, compared with the same number of days before (default 7)")),
+		mcp.WithNumber("count", mcp.Description("Number of top values to return (default 10)")),
+	)
+
+	s.AddTool(licenseTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		splitBy := "index"
+		days := 7
+		count := 10
+		if v, ok := request.Params.Arguments["split_by"].(string); ok && v != "" {
+			splitBy = v
+		}
+		if v, ok := request.Params.Arguments["days"].(float64); ok && v >= 1 {
+			days = int(v)
+			if days > 90 {
+				days = 90
+			}
+		}
+		if v, ok := request.Params.Arguments["count"].(float64); ok {
+			count = int(v)
+			if count > 100 {
+				count = 100
+			}
+		}
+
+		report, err := client.GetLicenseUsage(ctx, splitBy, days, count)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get license usage: " + err.Error()), nil
+		}
+		note := fmt.Sprintf("License usage over the last %d whole days by %s, top %d. Maximum is 90 days and 100 values.", days, splitBy, count)
+		if len(report.LicenserErrors) > 0 {
+			note += " Some licenser data was unavailable (see licenser_errors); the usage data is complete."
+		}
+		return jsonResult(note, report)
+	})
+
+	//////////////////////
+	// METRICS //
+	//////////////////////
+	metricsTool := mcp.NewTool("list_splunk_metrics",
+		mcp.WithDescription("List metric names and their dimensions in metrics indexes (via mcatalog). Use list_splunk_indexes with datatype=metric to find metrics indexes."),
+		mcp.WithString("index", mcp.Description("Metrics index name or pattern (default \"*\")")),
+		mcp.WithString("metric_name", mcp.Description("Metric name pattern, e.g. \"cpu.*\" (default \"*\")")),
+		mcp.WithString("earliest", mcp.Description("Start of the time range (default \"-24h\")")),
+		mcp.WithString("latest", mcp.Description("End of the time range (default \"now\")")),
+		mcp.WithNumber("count", mcp.Description("Maximum number of metrics to return (default 100)")),
+	)
+
+	s.AddTool(metricsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index := "*"
+		metricName := "*"
+		earliest := "-24h"
+		latest := "now"
+		count := 100
+		if v, ok := request.Params.Arguments["index"].(string); ok && v != "" {
+			index = v
+		}
+		if v, ok := request.Params.Arguments["metric_name"].(string); ok && v != "" {
+			metricName = v
+		}
+		if v, ok := request.Params.Arguments["earliest"].(string); ok && v != "" {
+			earliest = v
+		}
+		if v, ok := request.Params.Arguments["latest"].(string); ok && v != "" {
+			latest = v
+		}
+		if v, ok := request.Params.Arguments["count"].(float64); ok {
+			count = int(v)
+			if count > 1000 {
+				count = 1000
+			}
+		}
+
+		metrics, err := client.GetMetrics(ctx, index, metricName, earliest, latest, count)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get metrics: " + err.Error()), nil
+		}
+		note := fmt.Sprintf("Showing up to %d metrics between %s and %s. Maximum per call is 1000.", count, earliest, latest)
+		result := map[string]interface{}{
+			"metrics": metrics,
+			"count":   count,
+		}
+		return jsonResult(note, result)
+	})
+
+	mstatsTool := mcp.NewTool("query_splunk_metrics",
+		mcp.WithDescription("Run an mstats query on a metrics index and return time series as JSON: one aggregation of one metric per span, optionally filtered and grouped by dimensions."),
+		mcp.WithString("index", mcp.Required(), mcp.Description("Metrics index")),
+		mcp.WithString("metric_name", mcp.Required(), mcp.Description("Metric name, e.g. \"cpu.usage\"")),
+		mcp.WithString("aggregation", mcp.Enum(splunk.MetricAggregations...), mcp.Description("Aggregation (default \"avg\")")),
+		mcp.WithString("span", mcp.Description("Time bucket size, e.g. \"5m\" (default \"1h\")")),
+		mcp.WithString("group_by", mcp.Description("Comma-separated dimensions to split the series by, e.g. \"host\" (optional)")),
+		mcp.WithString("filter", mcp.Description("Additional where terms on dimensions, e.g. \"host=web*\" (optional)")),
+		mcp.WithString("earliest", mcp.Description("Start of the time range (default \"-24h\")")),
+		mcp.WithString("latest", mcp.Description("End of the time range (default \"now\")")),
+		mcp.WithNumber("max_series", mcp.Description("Maximum number of series to return, largest first (default 20)")),
+	)
+
+	s.AddTool(mstatsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		query := splunk.MetricQuery{Aggregation: "avg", Span: "1h", Earliest: "-24h", Latest: "now"}
+		query.Index, _ = request.Params.Arguments["index"].(string)
+		query.Metric, _ = request.Params.Arguments["metric_name"].(string)
+		if query.Index == "" || query.Metric == "" {
+			return mcp.NewToolResultError("index and metric_name are required"), nil
+		}
+		if v, ok := request.Params.Arguments["aggregation"].(string); ok && v != "" {
+			query.Aggregation = v
+		}
+		if v, ok := request.Params.Arguments["span"].(string); ok && v != "" {
+			query.Span = v
+		}
+		if v, ok := request.Params.Arguments["group_by"].(string); ok {
+			query.GroupBy = splitFields(v)
+		}
+		query.Filter, _ = request.Params.Arguments["filter"].(string)
+		if v, ok := request.Params.Arguments["earliest"].(string); ok && v != "" {
+			query.Earliest = v
+		}
+		if v, ok := request.Params.Arguments["latest"].(string); ok && v != "" {
+			query.Latest = v
+		}
+		maxSeries := 20
+		if v, ok := request.Params.Arguments["max_series"].(float64); ok && v >= 1 {
+			maxSeries = int(v)
+			if maxSeries > 100 {
+				maxSeries = 100
+			}
+		}
+
+		result, err := client.QueryMetrics(ctx, query, maxSeries)
+		if err != nil {
+			return mcp.NewToolResultError("failed to query metrics: " + err.Error()), nil
+		}
+		note := fmt.Sprintf("%s(%s) per %s between %s and %s, up to %d series. Maximum is 100 series.", query.Aggregation, query.Metric, query.Span, query.Earliest, query.Latest, maxSeries)
+		return jsonResult(note, result)
+	})
+
+	//////////////////////
+	// FIELD SUMMARY //
+	//////////////////////
+	fieldSummaryTool := mcp.NewTool("get_splunk_field_summary",
+		mcp.WithDescription("Summarize the fields of a search before writing SPL against it: field names, coverage percentage, distinct count, top values and numeric min/max/mean (via fieldsummary over a sample of events), most common fields first."),
+		mcp.WithString("search", mcp.Required(), mcp.Description("Base search, e.g. \"index=okta sourcetype=OktaIM2:log\"")),
+		mcp.WithString("earliest", mcp.Description("Start of the time range (default \"-24h\")")),
+		mcp.WithString("latest", mcp.Description("End of the time range (default \"now\")")),
+		mcp.WithNumber("sample", mcp.Description("Number of events to summarize (default 10000)")),
+		mcp.WithNumber("max_fields", mcp.Description("Maximum number of fields to return (default 50)")),
+		mcp.WithNumber("max_values", mcp.Description("Maximum number of top values per field (default 5)")),
+	)
+
+	s.AddTool(fieldSummaryTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		spl, _ := request.Params.Arguments["search"].(string)
+		if spl == "" {
+			return mcp.NewToolResultError("search is required"), nil
+		}
+		earliest := "-24h"
+		latest := "now"
+		sample := 10000
+		maxFields := 50
+		maxValues := 5
+		if v, ok := request.Params.Arguments["earliest"].(string); ok && v != "" {
+			earliest = v
+		}
+		if v, ok := request.Params.Arguments["latest"].(string); ok && v != "" {
+			latest = v
+		}
+		if v, ok := request.Params.Arguments["sample"].(float64); ok && v >= 1 {
+			sample = int(v)
+			if sample > 100000 {
+				sample = 100000
+			}
+		}
+		if v, ok := request.Params.Arguments["max_fields"].(float64); ok && v >= 1 {
+			maxFields = int(v)
+			if maxFields > 200 {
+				maxFields = 200
+			}
+		}
+		if v, ok := request.Params.Arguments["max_values"].(float64); ok && v >= 1 {
+			maxValues = int(v)
+			if maxValues > 20 {
+				maxValues = 20
+			}
+		}
+
+		summary, err := client.GetFieldSummary(ctx, spl, earliest, latest, sample, maxFields, maxValues)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get field summary: " + err.Error()), nil
+		}
+		note := fmt.Sprintf("Field summary of %d events between %s and %s, up to %d fields with %d top values each. Maximum is 100000 events, 200 fields and 20 values.", summary.Events, earliest, latest, maxFields, maxValues)
+		return jsonResult(note, summary)
+	})
+
+	//////////////////////
+	// TIMECHART //
+	//////////////////////
+	timechartTool := mcp.NewTool("get_splunk_timechart",
+		mcp.WithDescription("Run a timechart search and return its results as normalized series (timestamps plus one values array per series, null for empty buckets) instead of wide rows, optionally downsampled to max_points."),
+		mcp.WithString("search", mcp.Required(), mcp.Description("SPL returning one row per time bucket, e.g. \"index=web | timechart span=5m count by status\"")),
+		mcp.WithString("earliest", mcp.Description("Start of the time range (default \"-24h\")")),
+		mcp.WithString("latest", mcp.Description("End of the time range (default \"now\")")),
+		mcp.WithNumber("max_points", mcp.Description("Maximum number of points per series; consecutive buckets are merged beyond it (default 200)")),
+		mcp.WithString("aggregate", mcp.Enum("avg", "sum", "min", "max", "last"), mcp.Description("How merged buckets are combined when downsampling (default \"avg\")")),
+	)
+
+	s.AddTool(timechartTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		spl, _ := request.Params.Arguments["search"].(string)
+		if spl == "" {
+			return mcp.NewToolResultError("search is required"), nil
+		}
+		earliest := "-24h"
+		latest := "now"
+		maxPoints := 200
+		if v, ok := request.Params.Arguments["earliest"].(string); ok && v != "" {
+			earliest = v
+		}
+		if v, ok := request.Params.Arguments["latest"].(string); ok && v != "" {
+			latest = v
+		}
+		if v, ok := request.Params.Arguments["max_points"].(float64); ok && v >= 1 {
+			maxPoints = int(v)
+			if maxPoints > 2000 {
+				maxPoints = 2000
+			}
+		}
+		aggregate, _ := request.Params.Arguments["aggregate"].(string)
+
+		chart, err := client.GetTimechart(ctx, spl, earliest, latest, maxPoints, aggregate)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get timechart: " + err.Error()), nil
+		}
+		note := fmt.Sprintf("%d series with %d points each, span %ds, between %s and %s. Maximum is 2000 points.", len(chart.Series), chart.Points, chart.SpanSeconds, earliest, latest)
+		if chart.Truncated {
+			note += " The search reached the limit of 10000 buckets, later buckets may be missing (truncated=true): use a larger span or a shorter time range."
+		}
+		return jsonResult(note, chart)
+	})
+
+	//////////////////////
+	// EVENT SAMPLES //
+	//////////////////////
+	sampleTool := mcp.NewTool("sample_splunk_events",
+		mcp.WithDescription("Return a handful of representative raw events of an index/sourcetype with their extracted fields, _raw truncated, by default one event per punct pattern (most common first) to show structural variety."),
+		mcp.WithString("index", mcp.Required(), mcp.Description("Index name")),
+		mcp.WithString("sourcetype", mcp.Description("Sourcetype (optional)")),
+		mcp.WithString("search", mcp.Description("Additional search terms, e.g. \"status=500\" (optional)")),
+		mcp.WithString("earliest", mcp.Description("Start of the time range (default \"-24h\")")),
+		mcp.WithString("latest", mcp.Description("End of the time range (default \"now\")")),
+		mcp.WithNumber("count", mcp.Description("Number of events to return (default 10)")),
+		mcp.WithNumber("raw_length", mcp.Description("Maximum length of _raw in characters (default 500)")),
+		mcp.WithNumber("sample_ratio", mcp.Description("Let Splunk read only 1 in sample_ratio events, e.g. 100 on large indexes (max 100000, default 1, no sampling)")),
+		mcp.WithBoolean("dedup_punct", mcp.Description("Return one event per punct pattern among the first 100000 events read (default true)")),
+	)
+
+	s.AddTool(sampleTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		index, _ := request.Params.Arguments["index"].(string)
+		if index == "" {
+			return mcp.NewToolResultError("index is required"), nil
+		}
+		spl := "index=" + splunk.QuoteSPL(index)
+		if v, ok := request.Params.Arguments["sourcetype"].(string); ok && v != "" {
+			spl += " sourcetype=" + splunk.QuoteSPL(v)
+		}
+		if v, ok := request.Params.Arguments["search"].(string); ok && v != "" {
+			spl += " " + v
+		}
+		earliest := "-24h"
+		latest := "now"
+		count := 10
+		rawLength := 500
+		sampleRatio := 1
+		if v, ok := request.Params.Arguments["earliest"].(string); ok && v != "" {
+			earliest = v
+		}
+		if v, ok := request.Params.Arguments["latest"].(string); ok && v != "" {
+			latest = v
+		}
+		if v, ok := request.Params.Arguments["count"].(float64); ok && v >= 1 {
+			count = int(v)
+			if count > 100 {
+				count = 100
+			}
+		}
+		if v, ok := request.Params.Arguments["raw_length"].(float64); ok && v >= 1 {
+			rawLength = int(v)
+			if rawLength > 5000 {
+				rawLength = 5000
+			}
+		}
+		if v, ok := request.Params.Arguments["sample_ratio"].(float64); ok && v >= 1 {
+			sampleRatio = int(v)
+			if sampleRatio > 100000 {
+				sampleRatio = 100000
+			}
+		}
+		dedupPunct := true
+		if v, ok := request.Params.Arguments["dedup_punct"].(bool); ok {
+			dedupPunct = v
+		}
+
+		events, err := client.SampleEvents(ctx, spl, earliest, latest, count, rawLength, sampleRatio, dedupPunct)
+		if err != nil {
+			return mcp.NewToolResultError("failed to sample events: " + err.Error()), nil
+		}
+		note := fmt.Sprintf("Showing up to %d events of %s between %s and %s, _raw truncated to %d characters. Punct deduplication reads at most the first 100000 events. Maximum is 100 events, 5000 characters and a sample_ratio of 100000.", count, spl, earliest, latest, rawLength)
+		result := map[string]interface{}{
+			"events": events,
+			"count":  count,
+		}
+		return jsonResult(note, result)
+	})
+
+	patternsTool := mcp.NewTool("get_splunk_event_patterns",
+		mcp.WithDescription("Summarize what a noisy source is emitting: group a sample of a search's events into patterns (cluster by _raw similarity or by punct) and return the top patterns with counts, share of the sample and one example each."),
+		mcp.WithString("search", mcp.Required(), mcp.Description("Base search, e.g. \"index=app sourcetype=java:log\"")),
+		mcp.WithString("earliest", mcp.Description("Start of the time range (default \"-24h\")")),
+		mcp.WithString("latest", mcp.Description("End of the time range (default \"now\")")),
+		mcp.WithString("method", mcp.Enum("cluster", "punct"), mcp.Description("Group by _raw similarity (cluster) or punctuation pattern (punct) (default \"cluster\")")),
+		mcp.WithNumber("threshold", mcp.Description("Cluster similarity threshold between 0 and 1, higher makes more, tighter patterns (default 0.8)")),
+		mcp.WithNumber("sample", mcp.Description("Number of events to group (default 10000)")),
+		mcp.WithNumber("count", mcp.Description("Number of patterns to return, largest first (default 20)")),
+		mcp.WithNumber("example_length", mcp.Description("Maximum length of each example in characters (default 300)")),
+	)
+
+	s.AddTool(patternsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		spl, _ := request.Params.Arguments["search"].(string)
+		if spl == "" {
+			return mcp.NewToolResultError("search is required"), nil
+		}
+		earliest := "-24h"
+		latest := "now"
+		method := "cluster"
+		threshold := 0.8
+		sample := 10000
+		count := 20
+		exampleLength := 300
+		if v, ok := request.Params.Arguments["earliest"].(string); ok && v != "" {
+			earliest = v
+		}
+		if v, ok := request.Params.Arguments["latest"].(string); ok && v != "" {
+			latest = v
+		}
+		if v, ok := request.Params.Arguments["method"].(string); ok && v != "" {
+			method = v
+		}
+		if v, ok := request.Params.Arguments["threshold"].(float64); ok && v > 0 && v < 1 {
+			threshold = v
+		}
+		if v, ok := request.Params.Arguments["sample"].(float64); ok && v >= 1 {
+			sample = int(v)
+			if sample > 100000 {
+				sample = 100000
+			}
+		}
+		if v, ok := request.Params.Arguments["count"].(float64); ok && v >= 1 {
+			count = int(v)
+			if count > 100 {
+				count = 100
+			}
+		}
+		if v, ok := request.Params.Arguments["example_length"].(float64); ok && v >= 1 {
+			exampleLength = int(v)
+			if exampleLength > 2000 {
+				exampleLength = 2000
+			}
+		}
+
+		report, err := client.GetEventPatterns(ctx, spl, earliest, latest, method, threshold, sample, count, exampleLength)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get event patterns: " + err.Error()), nil
+		}
+		note := fmt.Sprintf("Top %d patterns (%s) of %d events between %s and %s. Maximum is 100000 events and 100 patterns.", len(report.Patterns), method, report.Events, earliest, latest)
+		return jsonResult(note, report)
+	})
+
+	//////////////////////
+	// MACROS //
+	//////////////////////
+	macrosTool := mcp.NewTool("list_splunk_macros",
+		mcp.WithDescription("List Splunk macros (paginated by count and offset arguments)."),
+		mcp.WithNumber("count", mcp.Description("Number of results to return (default 10)")),
+		mcp.WithNumber("offset", mcp.Description("Offset for pagination (default 0)")),
+		mcp.WithString("name", mcp.Description("Case-insensitive substring of the macro name (optional)")),
+		mcp.WithString("search_text", mcp.Description("Substring of the macro definition (optional)")),
+		mcp.WithString("app", mcp.Description("Filter by the app the object belongs to (optional)")),
+		mcp.WithString("owner", mcp.Description("Filter by the object owner (optional)")),
+		mcp.WithBoolean("disabled", mcp.Description("Only return disabled (true) or enabled (false) macros (optional)")),
+		mcp.WithString("sort_key", mcp.Description("Field to sort by, e.g. name (optional)")),
+		mcp.WithString("sort_dir", mcp.Enum("asc", "desc"), mcp.Description("Sort direction (default asc)")),
+	)
+
+	s.AddTool(macrosTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		count := 10
+		offset := 0
+		if v, ok := request.Params.Arguments["count"].(float64); ok {
+			count = int(v)
+			if count > 100 {
+				count = 100
+			}
+		}
+		if v, ok := request.Params.Arguments["offset"].(float64); ok {
+			offset = int(v)
+		}
+
+		filter := listFilterFromArguments(request.Params.Arguments)
+
+		macros, total, err := client.GetMacros(ctx, count, offset, filter)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get macros: " + err.Error()), nil
+		}
+
+		note := fmt.Sprintf("Showing up to %d macros (as requested). Use 'offset' to paginate. Maximum per call is 100.", count)
+		result := map[string]interface{}{
+			"macros": macros,
+			"count":  count,
+			"offset": offset,
+			"total":  total,
+		}
+		return jsonResult(note, result)
+	})
+
+	//////////////////////
+	// MACRO EXPANSION //
+	//////////////////////
+	expandTool := mcp.NewTool("expand_splunk_search",
+		mcp.WithDescription("Recursively expand all macros (including argumented macros like `foo(1,2)`) in a Splunk search. Returns the fully expanded SPL and the expansion tree; macro cycles are reported as errors."),
+		mcp.WithString("search", mcp.Required(), mcp.Description("SPL to expand")),
+		mcp.WithString("app", mcp.Description("App context used to pick between macros with the same name in several apps (optional)")),
+		mcp.WithBoolean("cross_check", mcp.Description("Also return the result of Splunk's /services/search/parser for comparison (default false)")),
+	)
+
+	s.AddTool(expandTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		spl, _ := request.Params.Arguments["search"].(string)
+		if spl == "" {
+			return mcp.NewToolResultError("search is required"), nil
+		}
+		app, _ := request.Params.Arguments["app"].(string)
+		crossCheck, _ := request.Params.Arguments["cross_check"].(bool)
+
+		expansion, err := client.ExpandSearch(ctx, spl, app, crossCheck)
+		if err != nil {
+			return mcp.NewToolResultError("failed to expand search: " + err.Error()), nil
+		}
+		return jsonResult("", expansion)
+	})
+
+	//////////////////////
+	// DEPENDENCY GRAPH (saved searches, alerts, macros, eventtypes, lookups and dashboards linked by their SPL) //
+	//////////////////////
+	dependentsTool := mcp.NewTool("get_splunk_dependents",
+		mcp.WithDescription("List knowledge objects that depend on the given object, i.e. what breaks if it changes. Walks the dependency graph built from the SPL of saved searches, alerts, macros, eventtypes and dashboards."),
+		mcp.WithString("type", mcp.Required(), mcp.Enum("savedsearch", "alert", "macro", "eventtype", "lookup", "automatic_lookup", "dashboard"), mcp.Description("Object type")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Object name; argumented macros use Splunk naming, e.g. foo(2)")),
+		mcp.WithString("app", mcp.Description("App of the object, needed when objects with this name exist in several apps (optional)")),
+		mcp.WithString("owner", mcp.Description("Owner of the object, needed to pick a private object (optional)")),
+		mcp.WithNumber("depth", mcp.Description("Maximum number of levels to walk, 0 for unlimited (default 0)")),
+	)
+
+	s.AddTool(dependentsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		objectType, _ := request.Params.Arguments["type"].(string)
+		name, _ := request.Params.Arguments["name"].(string)
+		app, _ := request.Params.Arguments["app"].(string)
+		owner, _ := request.Params.Arguments["owner"].(string)
+		depth := 0
+		if v, ok := request.Params.Arguments["depth"].(float64); ok {
+			depth = int(v)
+		}
+
+		graph, err := client.BuildDependencyGraph(ctx)
+		if err != nil {
+			return mcp.NewToolResultError("failed to build dependency graph: " + err.Error()), nil
+		}
+		dependents, err := graph.Dependents(objectType, name, app, owner, depth)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get dependents: " + err.Error()), nil
+		}
+
+		result := map[string]interface{}{
+			"object":     objectType + ":" + name,
+			"dependents": dependents,
+			"total":      len(dependents),
+		}
+		return jsonResult("", result)
+	})
+
+	dependenciesTool := mcp.NewTool("get_splunk_dependencies",
+		mcp.WithDescription("List knowledge objects the given object depends on (macros, eventtypes, lookups, saved searches), walking the dependency graph transitively."),
+		mcp.WithString("type", mcp.Required(), mcp.Enum("savedsearch", "alert", "macro", "eventtype", "lookup", "automatic_lookup", "dashboard"), mcp.Description("Object type")),
+		mcp.WithString("name", mcp.Required(), mcp.Description("Object name; argumented macros use Splunk naming, e.g. foo(2)")),
+		mcp.WithString("app", mcp.Description("App of the object, needed when objects with this name exist in several apps (optional)")),
+		mcp.WithString("owner", mcp.Description("Owner of the object, needed to pick a private object (optional)")),
+		mcp.WithNumber("depth", mcp.Description("Maximum number of levels to walk, 0 for unlimited (default 0)")),
+	)
+
+	s.AddTool(dependenciesTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		objectType, _ := request.Params.Arguments["type"].(string)
+		name, _ := request.Params.Arguments["name"].(string)
+		app, _ := request.Params.Arguments["app"].(string)
+		owner, _ := request.Params.Arguments["owner"].(string)
+		depth := 0
+		if v, ok := request.Params.Arguments["depth"].(float64); ok {
+			depth = int(v)
+		}
+
+		graph, err := client.BuildDependencyGraph(ctx)
+		if err != nil {
+			return mcp.NewToolResultError("failed to build dependency graph: " + err.Error()), nil
+		}
+		dependencies, err := graph.Dependencies(objectType, name, app, owner, depth)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get dependencies: " + err.Error()), nil
+		}
+
+		result := map[string]interface{}{
+			"object":       objectType + ":" + name,
+			"dependencies": dependencies,
+			"total":        len(dependencies),
+		}
+		return jsonResult("", result)
+	})
+
+	graphExportTool := mcp.NewTool("export_splunk_dependency_graph",
+		mcp.WithDescription("Export the knowledge object dependency graph as Graphviz DOT or a Mermaid flowchart for documentation. Optionally limited to the neighbourhood of one object."),
+		mcp.WithString("format", mcp.Enum("dot", "mermaid"), mcp.Description("Output format (default \"mermaid\")")),
+		mcp.WithString("type", mcp.Enum("savedsearch", "alert", "macro", "eventtype", "lookup", "automatic_lookup", "dashboard"), mcp.Description("Type of the root object (optional, used with name)")),
+		mcp.WithString("name", mcp.Description("Only export this object with its dependencies and dependents (optional)")),
+		mcp.WithString("app", mcp.Description("App of the root object, needed when objects with this name exist in several apps (optional)")),
+		mcp.WithString("owner", mcp.Description("Owner of the root object, needed to pick a private object (optional)")),
+	)
+
+	s.AddTool(graphExportTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		format := "mermaid"
+		if v, ok := request.Params.Arguments["format"].(string); ok && v != "" {
+			format = v
+		}
+		objectType, _ := request.Params.Arguments["type"].(string)
+		name, _ := request.Params.Arguments["name"].(string)
+		app, _ := request.Params.Arguments["app"].(string)
+		owner, _ := request.Params.Arguments["owner"].(string)
+
+		graph, err := client.BuildDependencyGraph(ctx)
+		if err != nil {
+			return mcp.NewToolResultError("failed to build dependency graph: " + err.Error()), nil
+		}
+		out, err := graph.Export(format, objectType, name, app, owner)
+		if err != nil {
+			return mcp.NewToolResultError("failed to export dependency graph: " + err.Error()), nil
+		}
+		if len(out) > resultBudget.MaxBytes {
+			note := fmt.Sprintf("Graph exceeded the %d byte budget and was truncated. Pass type and name to export the neighbourhood of one object.", resultBudget.MaxBytes)
+			// cut at the last full line, so the output never ends inside a rune or a DOT/Mermaid statement
+			out = out[:resultBudget.MaxBytes]
+			if i := strings.LastIndexByte(out, '\n'); i >= 0 {
+				out = out[:i+1]
+			} else {
+				out = strings.ToValidUTF8(out, "")
+			}
+			out = note + "\n\n" + out
+		}
+		return mcp.NewToolResultText(out), nil
+	})
+
+	//////////////////////
+	// UNUSED AND ORPHANED KNOWLEDGE OBJECTS //
+	//////////////////////
+	unusedTool := mcp.NewTool("report_splunk_unused_objects",
+		mcp.WithDescription("Report macros, lookups and eventtypes not referenced by any saved search, alert, dashboard or automatic lookup (directly or through other macros/eventtypes), and knowledge objects whose owner no longer exists. Not referenced from SPL does not mean unused: eventtypes also apply implicitly and through tags, and lookups may be used ad hoc."),
+		mcp.WithString("app", mcp.Description("Only report objects belonging to this app (optional)")),
+	)
+
+	s.AddTool(unusedTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		app, _ := request.Params.Arguments["app"].(string)
+
+		report, err := client.GetUnusedObjects(ctx, app)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get unused objects: " + err.Error()), nil
+		}
+
+		note := fmt.Sprintf("Found %d knowledge objects not referenced from SPL or automatic lookups and %d orphaned ones. Check the note of eventtypes and lookups before deleting them.", len(report.Unreferenced), len(report.Orphaned))
+		return jsonResult(note, report)
+	})
+
+	//////////////////////
+	// APPS //
+	//////////////////////
+	appsTool := mcp.NewTool("list_splunk_apps",
+		mcp.WithDescription("List installed Splunk apps and add-ons with versions and update availability (paginated by count and offset arguments). Pass 'name' to get a single app with its knowledge object counts by type."),
+		mcp.WithNumber("count", mcp.Description("Number of results to return (default 10)")),
+		mcp.WithNumber("offset", mcp.Description("Offset for pagination (default 0)")),
+		mcp.WithString("name", mcp.Description("App name for detail mode, e.g. Splunk_TA_windows (optional)")),
+	)
+
+	s.AddTool(appsTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+		if name, ok := request.Params.Arguments["name"].(string); ok && name != "" {
+			app, err := client.GetApp(ctx, name)
+			if err != nil {
+				return mcp.NewToolResultError("failed to get app: " + err.Error()), nil
+			}
+			return jsonResult("", app)
+		}
+
+		count := 10
+		offset := 0
+		if v, ok := request.Params.Arguments["count"].(float64); ok {
+			count = int(v)
+			if count > 100 {
+				count = 100
+			}
+		}
+		if v, ok := request.Params.Arguments["offset"].(float64); ok {
+			offset = int(v)
+		}
+
+		apps, total, err := client.GetApps(ctx, count, offset)
+		if err != nil {
+			return mcp.NewToolResultError("failed to get apps: " + err.Error()), nil
+		}
+
+		note := fmt.Sprintf("Showing up to %d apps (as requested). Use 'offset' to paginate. Maximum per call is 100.", count)
+		result := map[string]interface{}{
+			"apps":   apps,
+			"count":  count,
+			"offset": offset,
+			"total":  total,
+		}
+		return jsonResult(note, result)
+	})
+
+	//////////////////////
+	// SAVED SEARCH WRITES (only with SPLUNK_WRITE_MODE enabled; create/update/clone/delete dry run first, then apply with the preview token) //
+	//////////////////////
+	if writeMode {
+		createSavedSearchTool := mcp.NewTool("create_splunk_saved_search",
+			mcp.WithDescription("Create a saved search or alert. Run with dry_run=true (default) to preview the diff, then again with dry_run=false and the returned preview_token to apply."),
+			mcp.WithString("name", mcp.Required(), mcp.Description("Saved search name")),
+			mcp.WithString("app", mcp.Description("App to create the saved search in (default \"search\")")),
+			mcp.WithString("search", mcp.Description("SPL of the saved search")),
+			mcp.WithString("description", mcp.Description("Description")),
+			mcp.WithString("cron_schedule", mcp.Description("Cron schedule, e.g. \"*/15 * * * *\" (also set is_scheduled in settings)")),
+			mcp.WithString("earliest_time", mcp.Description("dispatch.earliest_time, e.g. \"-15m\"")),
+			mcp.WithString("latest_time", mcp.Description("dispatch.latest_time, e.g. \"now\"")),
+			mcp.WithString("actions", mcp.Description("Comma-separated alert actions, e.g. \"email,webhook\"")),
+			mcp.WithObject("settings", mcp.Description("Other saved search settings by Splunk name: schedule, alert condition, alert.* and action.* settings, e.g. {\"is_scheduled\": true, \"action.email.to\": \"soc@example.com\", \"alert.suppress\": true}")),
+			mcp.WithBoolean("dry_run", mcp.Description("Preview the diff without changing anything (default true)")),
+			mcp.WithString("preview_token", mcp.Description("preview_token returned by the dry run; required with dry_run=false")),
+		)
+
+		s.AddTool(createSavedSearchTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, _ := request.Params.Arguments["name"].(string)
+			app := "search"
+			if v, ok := request.Params.Arguments["app"].(string); ok && v != "" {
+				app = v
+			}
+			dryRun, previewToken := writeArguments(request.Params.Arguments)
+
+			settings, err := savedSearchSettings(request.Params.Arguments)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			preview, err := client.CreateSavedSearch(ctx, name, app, settings, dryRun, previewToken)
+			return writeResult(preview, err)
+		})
+
+		updateSavedSearchTool := mcp.NewTool("update_splunk_saved_search",
+			mcp.WithDescription("Update the search, schedule, actions or any other settings of a saved search or alert. Run with dry_run=true (default) to preview the diff, then again with dry_run=false and the returned preview_token to apply."),
+			mcp.WithString("name", mcp.Required(), mcp.Description("Saved search name")),
+			mcp.WithString("app", mcp.Description("App the saved search belongs to, when the name exists in several apps (optional)")),
+			mcp.WithString("search", mcp.Description("SPL of the saved search")),
+			mcp.WithString("description", mcp.Description("Description")),
+			mcp.WithString("cron_schedule", mcp.Description("Cron schedule, e.g. \"*/15 * * * *\" (also set is_scheduled in settings)")),
+			mcp.WithString("earliest_time", mcp.Description("dispatch.earliest_time, e.g. \"-15m\"")),
+			mcp.WithString("latest_time", mcp.Description("dispatch.latest_time, e.g. \"now\"")),
+			mcp.WithString("actions", mcp.Description("Comma-separated alert actions, e.g. \"email,webhook\"")),
+			mcp.WithObject("settings", mcp.Description("Other saved search settings by Splunk name: schedule, alert condition, alert.* and action.* settings, e.g. {\"is_scheduled\": true, \"action.email.to\": \"soc@example.com\", \"alert.suppress\": true}")),
+			mcp.WithBoolean("dry_run", mcp.Description("Preview the diff without changing anything (default true)")),
+			mcp.WithString("preview_token", mcp.Description("preview_token returned by the dry run; required with dry_run=false")),
+		)
+
+		s.AddTool(updateSavedSearchTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, _ := request.Params.Arguments["name"].(string)
+			app, _ := request.Params.Arguments["app"].(string)
+			dryRun, previewToken := writeArguments(request.Params.Arguments)
+
+			settings, err := savedSearchSettings(request.Params.Arguments)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			preview, err := client.UpdateSavedSearch(ctx, name, app, settings, dryRun, previewToken)
+			return writeResult(preview, err)
+		})
+
+		cloneSavedSearchTool := mcp.NewTool("clone_splunk_saved_search",
+			mcp.WithDescription("Clone a saved search or alert under a new name, optionally overriding settings. Run with dry_run=true (default) to preview, then again with dry_run=false and the returned preview_token to apply."),
+			mcp.WithString("name", mcp.Required(), mcp.Description("Name of the saved search to clone")),
+			mcp.WithString("app", mcp.Description("App the source saved search belongs to (optional)")),
+			mcp.WithString("new_name", mcp.Required(), mcp.Description("Name of the copy")),
+			mcp.WithString("new_app", mcp.Description("App to create the copy in (default: the source app)")),
+			mcp.WithString("search", mcp.Description("SPL of the saved search")),
+			mcp.WithString("description", mcp.Description("Description")),
+			mcp.WithString("cron_schedule", mcp.Description("Cron schedule, e.g. \"*/15 * * * *\" (also set is_scheduled in settings)")),
+			mcp.WithString("earliest_time", mcp.Description("dispatch.earliest_time, e.g. \"-15m\"")),
+			mcp.WithString("latest_time", mcp.Description("dispatch.latest_time, e.g. \"now\"")),
+			mcp.WithString("actions", mcp.Description("Comma-separated alert actions, e.g. \"email,webhook\"")),
+			mcp.WithObject("settings", mcp.Description("Other saved search settings by Splunk name: schedule, alert condition, alert.* and action.* settings, e.g. {\"is_scheduled\": true, \"action.email.to\": \"soc@example.com\", \"alert.suppress\": true}")),
+			mcp.WithBoolean("dry_run", mcp.Description("Preview the diff without changing anything (default true)")),
+			mcp.WithString("preview_token", mcp.Description("preview_token returned by the dry run; required with dry_run=false")),
+		)
+
+		s.AddTool(cloneSavedSearchTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, _ := request.Params.Arguments["name"].(string)
+			app, _ := request.Params.Arguments["app"].(string)
+			newName, _ := request.Params.Arguments["new_name"].(string)
+			newApp, _ := request.Params.Arguments["new_app"].(string)
+			if newName == "" {
+				return mcp.NewToolResultError("new_name is required"), nil
+			}
+			dryRun, previewToken := writeArguments(request.Params.Arguments)
+
+			settings, err := savedSearchSettings(request.Params.Arguments)
+			if err != nil {
+				return mcp.NewToolResultError(err.Error()), nil
+			}
+			preview, err := client.CloneSavedSearch(ctx, name, app, newName, newApp, settings, dryRun, previewToken)
+			return writeResult(preview, err)
+		})
+
+		deleteSavedSearchTool := mcp.NewTool("delete_splunk_saved_search",
+			mcp.WithDescription("Delete a saved search or alert. Run with dry_run=true (default) to preview what is removed, then again with dry_run=false and the returned preview_token to apply."),
+			mcp.WithString("name", mcp.Required(), mcp.Description("Saved search name")),
+			mcp.WithString("app", mcp.Description("App the saved search belongs to, when the name exists in several apps (optional)")),
+			mcp.WithBoolean("dry_run", mcp.Description("Preview the diff without changing anything (default true)")),
+			mcp.WithString("preview_token", mcp.Description("preview_token returned by the dry run; required with dry_run=false")),
+		)
+
+		s.AddTool(deleteSavedSearchTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, _ := request.Params.Arguments["name"].(string)
+			app, _ := request.Params.Arguments["app"].(string)
+			dryRun, previewToken := writeArguments(request.Params.Arguments)
+
+			preview, err := client.DeleteSavedSearch(ctx, name, app, dryRun, previewToken)
+			return writeResult(preview, err)
+		})
+
+		setSuppressionTool := mcp.NewTool("set_splunk_alert_suppression",
+			mcp.WithDescription("Enable, change or disable suppression (throttling) of an alert, e.g. suppress per host for 1h. Run with dry_run=true (default) to preview the diff, then again with dry_run=false and the returned preview_token to apply."),
+			mcp.WithString("name", mcp.Required(), mcp.Description("Alert name")),
+			mcp.WithString("app", mcp.Description("App the alert belongs to, when the name exists in several apps (optional)")),
+			mcp.WithBoolean("suppress", mcp.Required(), mcp.Description("alert.suppress: enable (true) or disable (false) suppression")),
+			mcp.WithString("fields", mcp.Description("alert.suppress.fields: comma-separated fields to suppress by, e.g. \"host\" (optional)")),
+			mcp.WithString("period", mcp.Description("alert.suppress.period, e.g. \"1h\" (required when enabling unless already set)")),
+			mcp.WithBoolean("dry_run", mcp.Description("Preview the diff without changing anything (default true)")),
+			mcp.WithString("preview_token", mcp.Description("preview_token returned by the dry run; required with dry_run=false")),
+		)
+
+		s.AddTool(setSuppressionTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, _ := request.Params.Arguments["name"].(string)
+			app, _ := request.Params.Arguments["app"].(string)
+			suppress, ok := request.Params.Arguments["suppress"].(bool)
+			if !ok {
+				return mcp.NewToolResultError("suppress is required"), nil
+			}
+			fields, _ := request.Params.Arguments["fields"].(string)
+			period, _ := request.Params.Arguments["period"].(string)
+			dryRun, previewToken := writeArguments(request.Params.Arguments)
+
+			preview, err := client.SetAlertSuppression(ctx, name, app, suppress, fields, period, dryRun, previewToken)
+			return writeResult(preview, err)
+		})
+
+		enableSavedSearchTool := mcp.NewTool("enable_splunk_saved_search",
+			mcp.WithDescription("Enable a saved search or alert."),
+			mcp.WithString("name", mcp.Required(), mcp.Description("Saved search name")),
+			mcp.WithString("app", mcp.Description("App the saved search belongs to, when the name exists in several apps (optional)")),
+		)
+
+		s.AddTool(enableSavedSearchTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, _ := request.Params.Arguments["name"].(string)
+			app, _ := request.Params.Arguments["app"].(string)
+
+			state, err := client.SetSavedSearchDisabled(ctx, name, app, false)
+			if err != nil {
+				return mcp.NewToolResultError("failed to enable saved search: " + err.Error()), nil
+			}
+			return jsonResult("", state)
+		})
+
+		disableSavedSearchTool := mcp.NewTool("disable_splunk_saved_search",
+			mcp.WithDescription("Disable a saved search or alert, e.g. to mute a flapping alert during an incident."),
+			mcp.WithString("name", mcp.Required(), mcp.Description("Saved search name")),
+			mcp.WithString("app", mcp.Description("App the saved search belongs to, when the name exists in several apps (optional)")),
+		)
+
+		s.AddTool(disableSavedSearchTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, _ := request.Params.Arguments["name"].(string)
+			app, _ := request.Params.Arguments["app"].(string)
+
+			state, err := client.SetSavedSearchDisabled(ctx, name, app, true)
+			if err != nil {
+				return mcp.NewToolResultError("failed to disable saved search: " + err.Error()), nil
+			}
+			return jsonResult("", state)
+		})
+
+		dispatchSavedSearchTool := mcp.NewTool("dispatch_splunk_saved_search",
+			mcp.WithDescription("Run a saved search or report immediately and return the SID of the job. Fetch its results with get_splunk_job_results."),
+			mcp.WithString("name", mcp.Required(), mcp.Description("Saved search name")),
+			mcp.WithString("app", mcp.Description("App the saved search belongs to, when the name exists in several apps (optional)")),
+			mcp.WithString("earliest", mcp.Description("Override dispatch.earliest_time, e.g. \"-4h\" (optional)")),
+			mcp.WithString("latest", mcp.Description("Override dispatch.latest_time, e.g. \"now\" (optional)")),
+			mcp.WithBoolean("trigger_actions", mcp.Description("Also run the alert actions if the alert condition is met (default false)")),
+		)
+
+		s.AddTool(dispatchSavedSearchTool, func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+			name, _ := request.Params.Arguments["name"].(string)
+			app, _ := request.Params.Arguments["app"].(string)
+			earliest, _ := request.Params.Arguments["earliest"].(string)
+			latest, _ := request.Params.Arguments["latest"].(string)
+			triggerActions, _ := request.Params.Arguments["trigger_actions"].(bool)
+
+			sid, err := client.DispatchSavedSearch(ctx, name, app, earliest, latest, triggerActions)
+			if err != nil {
+				return mcp.NewToolResultError("failed to dispatch saved search: " + err.Error()), nil
+			}
+			note := "Dispatched. Use get_splunk_job_results with this sid once the job is done."
+			return jsonResult(note, map[string]interface{}{"name": name, "sid": sid})
+		})
+	}
+
+	//////////////////////
+	// REGISTER ALL RESOURCES //
+	//////////////////////
+	// Register data dictionary resource
+	dataDictResource := mcp.NewResource(
+		"docs://data-dictionary",
+		"Data Dictionary",
+		mcp.WithResourceDescription("Splunk Data Dictionary containing information about data sources, criticality, indexes, sourcetypes, contact persons, and their metadata"),
+		mcp.WithMIMEType("text/csv"),
+	)
+
+	s.AddResource(dataDictResource, func(ctx context.Context, request mcp.ReadResourceRequest) ([]mcp.ResourceContents, error) {
+		content, err := os.ReadFile(resourcePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read data dictionary from %s: %v", resourcePath, err)
+		}
+
+		return []mcp.ResourceContents{
+			mcp.TextResourceContents{
+				URI:      "docs://data-dictionary",
+				MIMEType: "text/csv",
+				Text:     string(content),
+			},
+		}, nil
+	})
+
+	// Start the server
+	if *transport == "sse" {
 		addr := fmt.Sprintf(":%d", *port)
 		log.Printf("Starting SSE server on %s", addr)
 		sseServer := server.NewSSEServer(s)
@@ -321,3 +1584,122 @@ func main() {
 		}
 	}
 }
+
+// splitFields parses a comma-separated `fields` tool argument
+func splitFields(fields string) []string {
+	var result []string
+	for _, f := range strings.Split(fields, ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// listFilterFromArguments reads the server-side filter and sort arguments shared by the REST list tools
+func listFilterFromArguments(args map[string]interface{}) splunk.ListFilter {
+	var filter splunk.ListFilter
+	filter.Name, _ = args["name"].(string)
+	filter.Text, _ = args["search_text"].(string)
+	filter.App, _ = args["app"].(string)
+	filter.Owner, _ = args["owner"].(string)
+	filter.SortKey, _ = args["sort_key"].(string)
+	filter.SortDir, _ = args["sort_dir"].(string)
+	filter.DataType, _ = args["datatype"].(string)
+	if v, ok := args["disabled"].(bool); ok {
+		filter.Disabled = &v
+	}
+	if v, ok := args["scheduled"].(bool); ok {
+		filter.Scheduled = &v
+	}
+	return filter
+}
+
+// writeArguments reads the dry_run (default true) and preview_token arguments of the write tools
+func writeArguments(args map[string]interface{}) (bool, string) {
+	dryRun := true
+	if v, ok := args["dry_run"].(bool); ok {
+		dryRun = v
+	}
+	previewToken, _ := args["preview_token"].(string)
+	return dryRun, previewToken
+}
+
+// savedSearchArguments maps the convenience arguments of the write tools to Splunk setting names
+var savedSearchArguments = map[string]string{
+	"search":        "search",
+	"description":   "description",
+	"cron_schedule": "cron_schedule",
+	"earliest_time": "dispatch.earliest_time",
+	"latest_time":   "dispatch.latest_time",
+	"actions":       "actions",
+}
+
+// savedSearchSettings collects the saved search settings of a write tool call, mapping convenience arguments to Splunk names.
+// The free-form settings object only takes allowlisted settings that have no argument of their own.
+func savedSearchSettings(args map[string]interface{}) (map[string]string, error) {
+	explicit := map[string]string{}
+	for arg, setting := range savedSearchArguments {
+		explicit[setting] = arg
+	}
+
+	settings := map[string]string{}
+	if extra, ok := args["settings"].(map[string]interface{}); ok {
+		for k, v := range extra {
+			if arg, ok := explicit[k]; ok {
+				return nil, fmt.Errorf("set %s with the %s argument, not in settings", k, arg)
+			}
+			if !splunk.IsWritableSavedSearchSetting(k) {
+				return nil, fmt.Errorf("setting %q cannot be changed, settings accepts schedule, alert condition, alert.* and action.* settings", k)
+			}
+			switch v := v.(type) {
+			case bool:
+				if v {
+					settings[k] = "1"
+				} else {
+					settings[k] = "0"
+				}
+			default:
+				settings[k] = fmt.Sprintf("%v", v)
+			}
+		}
+	}
+	for arg, setting := range savedSearchArguments {
+		if v, ok := args[arg].(string); ok && v != "" {
+			settings[setting] = v
+		}
+	}
+	return settings, nil
+}
+
+// jsonResult renders v as JSON after the note, shaped to fit the result budget; what was cut is appended to the note
+func jsonResult(note string, v interface{}) (*mcp.CallToolResult, error) {
+	data, cut, err := resultBudget.Shape(v)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal results: " + err.Error()), nil
+	}
+	if cut != nil {
+		note = strings.TrimSpace(note + " " + cut.Note())
+	}
+	if note == "" {
+		return mcp.NewToolResultText(string(data)), nil
+	}
+	return mcp.NewToolResultText(note + "\n\n" + string(data)), nil
+}
+
+// writeResult renders the preview of a write tool, telling the caller how to apply a dry run.
+// Previews bypass the result budget: the preview_token vouches for the exact diff, so the caller must see all of it.
+func writeResult(preview *splunk.WritePreview, err error) (*mcp.CallToolResult, error) {
+	if err != nil {
+		return mcp.NewToolResultError("write failed: " + err.Error()), nil
+	}
+	note := "Applied."
+	if !preview.Applied {
+		note = "Dry run, nothing was changed. Review the changes and call again with dry_run=false and this preview_token to apply."
+	}
+	data, err := json.Marshal(preview)
+	if err != nil {
+		return mcp.NewToolResultError("failed to marshal results: " + err.Error()), nil
+	}
+	return mcp.NewToolResultText(note + "\n\n" + string(data)), nil
+}
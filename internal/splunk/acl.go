@@ -0,0 +1,32 @@
+package splunk
+
+// ACL carries the app context, ownership and permissions of a knowledge object.
+// It is embedded in the list types, so its fields appear flattened in the MCP response.
+type ACL struct {
+	App        string   `json:"app"`
+	Owner      string   `json:"owner"`
+	Sharing    string   `json:"sharing"`
+	ReadRoles  []string `json:"read_roles"`
+	WriteRoles []string `json:"write_roles"`
+}
+
+// restACL is the acl block Splunk returns with each entry of a REST list endpoint
+type restACL struct {
+	App     string `json:"app"`
+	Owner   string `json:"owner"`
+	Sharing string `json:"sharing"`
+	Perms   struct {
+		Read  []string `json:"read"`
+		Write []string `json:"write"`
+	} `json:"perms"`
+}
+
+func (a restACL) toACL() ACL {
+	return ACL{
+		App:        a.App,
+		Owner:      a.Owner,
+		Sharing:    a.Sharing,
+		ReadRoles:  a.Perms.Read,
+		WriteRoles: a.Perms.Write,
+	}
+}
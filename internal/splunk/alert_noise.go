@@ -0,0 +1,139 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// AlertNoise is the firing statistics of one saved search over the window, compared with the window before it.
+// Burstiness is (σ-μ)/(σ+μ) of the hourly fire counts: -1 for perfectly regular firing, around 0 for random, towards 1 for bursts.
+// ChangePct is nil when the alert did not fire in the previous window.
+type AlertNoise struct {
+	SavedSearch   string   `json:"ss_name"`
+	Fires         int      `json:"fires"`
+	PreviousFires int      `json:"previous_fires"`
+	ChangePct     *float64 `json:"change_pct"`
+	RatePerDay    float64  `json:"rate_per_day"`
+	MaxPerHour    int      `json:"max_per_hour"`
+	ActiveHours   int      `json:"active_hours"`
+	Burstiness    float64  `json:"burstiness"`
+	HourOfDay     [24]int  `json:"hour_of_day"`
+}
+
+// AlertNoiseReport ranks the noisiest alerts and the ones that fired in the previous window but went silent in the current one.
+type AlertNoiseReport struct {
+	Days     int          `json:"days"`
+	Noisiest []AlertNoise `json:"noisiest"`
+	Silent   []AlertNoise `json:"silent"`
+}
+
+// GetAlertNoise aggregates index=_audit action=alert_fired per saved search and hour over the last days and the days before,
+// and returns the top count noisiest and silent alerts
+func (c *Client) GetAlertNoise(ctx context.Context, days, count int, ssName string) (*AlertNoiseReport, error) {
+	// Windows are aligned to whole hours and computed once, so the search range and the window split use the same boundaries
+	end := time.Now().Truncate(time.Hour)
+	start := end.Add(-time.Duration(days) * 24 * time.Hour)
+	previousStart := start.Add(-time.Duration(days) * 24 * time.Hour)
+
+	spl := fmt.Sprintf("search index=_audit action=alert_fired ss_name=%s earliest=%d latest=%d | bin _time span=1h aligntime=%d | stats count by ss_name _time",
+		QuoteSPL(ssName), previousStart.Unix(), end.Unix(), previousStart.Unix())
+
+	rows, err := c.exportSearch(ctx, spl)
+	if err != nil {
+		return nil, err
+	}
+
+	hours := days * 24
+
+	stats := map[string]*AlertNoise{}
+	hourly := map[string][]int{}
+	for _, row := range rows {
+		name := getString(row, "ss_name")
+		t, err := time.Parse(time.RFC3339Nano, getString(row, "_time"))
+		if name == "" || err != nil {
+			continue
+		}
+		n := getInt(row, "count")
+
+		s, ok := stats[name]
+		if !ok {
+			s = &AlertNoise{SavedSearch: name}
+			stats[name] = s
+		}
+		if t.Before(start) {
+			s.PreviousFires += n
+			continue
+		}
+		s.Fires += n
+		s.ActiveHours++
+		s.HourOfDay[t.Hour()] += n
+		if n > s.MaxPerHour {
+			s.MaxPerHour = n
+		}
+		hourly[name] = append(hourly[name], n)
+	}
+
+	report := &AlertNoiseReport{Days: days, Noisiest: []AlertNoise{}, Silent: []AlertNoise{}}
+	for name, s := range stats {
+		s.RatePerDay = math.Round(float64(s.Fires)/float64(days)*100) / 100
+		s.Burstiness = burstiness(hourly[name], hours)
+		if s.PreviousFires > 0 {
+			change := math.Round(float64(s.Fires-s.PreviousFires)/float64(s.PreviousFires)*1000) / 10
+			s.ChangePct = &change
+		}
+		if s.Fires > 0 {
+			report.Noisiest = append(report.Noisiest, *s)
+		} else {
+			report.Silent = append(report.Silent, *s)
+		}
+	}
+
+	sort.Slice(report.Noisiest, func(i, j int) bool {
+		a, b := report.Noisiest[i], report.Noisiest[j]
+		if a.Fires != b.Fires {
+			return a.Fires > b.Fires
+		}
+		return a.SavedSearch < b.SavedSearch
+	})
+	sort.Slice(report.Silent, func(i, j int) bool {
+		a, b := report.Silent[i], report.Silent[j]
+		if a.PreviousFires != b.PreviousFires {
+			return a.PreviousFires > b.PreviousFires
+		}
+		return a.SavedSearch < b.SavedSearch
+	})
+	if len(report.Noisiest) > count {
+		report.Noisiest = report.Noisiest[:count]
+	}
+	if len(report.Silent) > count {
+		report.Silent = report.Silent[:count]
+	}
+	return report, nil
+}
+
+// burstiness computes (σ-μ)/(σ+μ) over total hourly buckets, of which only the non-zero ones are passed in
+func burstiness(nonZero []int, total int) float64 {
+	if total == 0 || len(nonZero) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, n := range nonZero {
+		sum += float64(n)
+	}
+	mean := sum / float64(total)
+
+	var variance float64
+	for _, n := range nonZero {
+		variance += (float64(n) - mean) * (float64(n) - mean)
+	}
+	variance += float64(total-len(nonZero)) * mean * mean
+	sigma := math.Sqrt(variance / float64(total))
+
+	if sigma+mean == 0 {
+		return 0
+	}
+	return math.Round((sigma-mean)/(sigma+mean)*100) / 100
+}
@@ -0,0 +1,62 @@
+package splunk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+// AuditEntry records one write operation (or its dry run) against Splunk.
+type AuditEntry struct {
+	Time      string        `json:"time"`
+	Operation string        `json:"operation"`
+	Object    string        `json:"object"`
+	App       string        `json:"app,omitempty"`
+	DryRun    bool          `json:"dry_run"`
+	Changes   []FieldChange `json:"changes,omitempty"`
+	SID       string        `json:"sid,omitempty"`
+	Result    string        `json:"result"`
+	Error     string        `json:"error,omitempty"`
+}
+
+// AuditLog writes AuditEntry records as JSON lines.
+type AuditLog struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+// NewAuditLog appends to the file at path, or logs to stderr when path is empty
+// (stdout is reserved for the STDIO transport)
+func NewAuditLog(path string) (*AuditLog, error) {
+	if path == "" {
+		return &AuditLog{w: log.Writer()}, nil
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open audit log: %w", err)
+	}
+	return &AuditLog{w: f}, nil
+}
+
+// Record writes an entry; failures are logged and never fail the operation being audited
+func (a *AuditLog) Record(entry AuditEntry) {
+	if a == nil {
+		return
+	}
+	entry.Time = time.Now().UTC().Format(time.RFC3339)
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("failed to marshal audit entry: %v", err)
+		return
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if _, err := a.w.Write(append(data, '\n')); err != nil {
+		log.Printf("failed to write audit entry: %v", err)
+	}
+}
@@ -0,0 +1,270 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Backtest guardrails: the number of jobs a single backtest may run and the example results kept per triggered slice
+const (
+	MaxBacktestSlices       = 200
+	backtestExamplesPerHit  = 3
+	backtestSlicesWithHits  = 10
+	defaultBacktestInterval = time.Hour
+)
+
+// BacktestCondition is the trigger condition of an alert: alert_type, alert_comparator and alert_threshold,
+// or a custom alert_condition search applied to the results.
+type BacktestCondition struct {
+	Type       string `json:"alert_type"`
+	Comparator string `json:"alert_comparator,omitempty"`
+	Threshold  string `json:"alert_threshold,omitempty"`
+	Custom     string `json:"alert_condition,omitempty"`
+}
+
+// BacktestSlice is one scheduled run of the search over its lookback; Examples are only kept for the first triggered slices.
+type BacktestSlice struct {
+	Earliest  string                   `json:"earliest"`
+	Latest    string                   `json:"latest"`
+	Count     int                      `json:"count"`
+	Triggered bool                     `json:"triggered"`
+	Examples  []map[string]interface{} `json:"examples,omitempty"`
+	Error     string                   `json:"error,omitempty"`
+}
+
+// BacktestReport is how often an alert would have fired over a past window.
+type BacktestReport struct {
+	Search    string            `json:"search"`
+	Interval  string            `json:"interval"`
+	Lookback  string            `json:"lookback"`
+	Condition BacktestCondition `json:"condition"`
+	Runs      int               `json:"runs"`
+	Triggered int               `json:"triggered"`
+	Failed    int               `json:"failed"`
+	Slices    []BacktestSlice   `json:"slices"`
+}
+
+// BacktestSavedSearch backtests a saved search with its own condition; runs are spaced by interval or by its cron schedule,
+// and each run looks back over its dispatch.earliest_time window
+func (c *Client) BacktestSavedSearch(ctx context.Context, name, app string, days int, interval string) (*BacktestReport, error) {
+	search, err := c.GetSavedSearch(ctx, name, app)
+	if err != nil {
+		return nil, err
+	}
+
+	condition := BacktestCondition{
+		Type:       search.AlertType,
+		Comparator: search.AlertComparator,
+		Threshold:  search.AlertThreshold,
+		Custom:     search.AlertCondition,
+	}
+
+	step, lookback, err := scheduleInterval(search.DispatchEarliestTime, search.CronSchedule)
+	if interval != "" {
+		step, err = ParseInterval(interval)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return c.Backtest(ctx, search.Search, condition, days, step, lookback)
+}
+
+// Backtest evaluates spl once every step over the last days, each run covering the lookback before it (step when 0),
+// with at most DefaultMaxConcurrentJobs jobs at a time, and reports the slices in which condition would have triggered
+func (c *Client) Backtest(ctx context.Context, spl string, condition BacktestCondition, days int, step, lookback time.Duration) (*BacktestReport, error) {
+	if step <= 0 {
+		step = defaultBacktestInterval
+	}
+	if lookback <= 0 {
+		lookback = step
+	}
+	end := time.Now().Truncate(step)
+	start := end.Add(-time.Duration(days) * 24 * time.Hour)
+	runs := int(end.Sub(start) / step)
+	if runs > MaxBacktestSlices {
+		return nil, fmt.Errorf("backtest would run %d searches, the limit is %d: use a shorter window or a larger interval", runs, MaxBacktestSlices)
+	}
+	if condition.Type == "" {
+		condition.Type = "number of events"
+	}
+	if condition.Comparator == "" && condition.Type != "always" && condition.Type != "custom" {
+		condition.Comparator = "greater than"
+		condition.Threshold = "0"
+	}
+
+	jobSPL := spl
+	if condition.Type == "custom" && condition.Custom != "" {
+		jobSPL = strings.TrimSpace(spl) + " | search " + condition.Custom
+	}
+
+	report := &BacktestReport{
+		Search:    spl,
+		Interval:  step.String(),
+		Lookback:  lookback.String(),
+		Condition: condition,
+		Runs:      runs,
+		Slices:    make([]BacktestSlice, runs),
+	}
+	counts := make([]int, runs)
+
+	// run i is dispatched at the end of the i-th step and searches the lookback before it
+	sliceEnd := func(i int) time.Time { return start.Add(time.Duration(i+1) * step) }
+	for i := range report.Slices {
+		report.Slices[i] = BacktestSlice{
+			Earliest: sliceEnd(i).Add(-lookback).Format(time.RFC3339),
+			Latest:   sliceEnd(i).Format(time.RFC3339),
+		}
+	}
+
+	// a fixed pool of workers, one per job slot, takes the slices in order
+	slices := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < cap(c.jobSlots) && w < runs; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range slices {
+				job, examples, err := c.RunJob(ctx, jobSPL, strconv.FormatInt(sliceEnd(i).Add(-lookback).Unix(), 10), strconv.FormatInt(sliceEnd(i).Unix(), 10), backtestExamplesPerHit)
+				if err != nil {
+					report.Slices[i].Error = err.Error()
+					continue
+				}
+				counts[i] = job.ResultCount
+				if condition.Type == "number of events" {
+					counts[i] = job.EventCount
+				}
+				report.Slices[i].Count = counts[i]
+				report.Slices[i].Examples = examples
+			}
+		}()
+	}
+	for i := 0; i < runs; i++ {
+		slices <- i
+	}
+	close(slices)
+	wg.Wait()
+
+	hits := 0
+	for i := range report.Slices {
+		slice := &report.Slices[i]
+		if slice.Error != "" {
+			report.Failed++
+			continue
+		}
+		previous := -1
+		if i > 0 && report.Slices[i-1].Error == "" {
+			previous = counts[i-1]
+		}
+		slice.Triggered = conditionMet(condition, counts[i], previous)
+		if slice.Triggered {
+			report.Triggered++
+			hits++
+		}
+		if !slice.Triggered || hits > backtestSlicesWithHits {
+			slice.Examples = nil
+		}
+	}
+	return report, nil
+}
+
+// conditionMet evaluates an alert condition on a slice count; previous is the count of the slice before (-1 if unknown)
+func conditionMet(condition BacktestCondition, count, previous int) bool {
+	switch condition.Type {
+	case "always":
+		return true
+	case "custom":
+		return count > 0
+	}
+
+	threshold, err := strconv.Atoi(strings.TrimSpace(condition.Threshold))
+	if err != nil {
+		threshold = 0
+	}
+	switch condition.Comparator {
+	case "greater than":
+		return count > threshold
+	case "less than":
+		return count < threshold
+	case "equal to":
+		return count == threshold
+	case "not equal to":
+		return count != threshold
+	case "rises by":
+		return previous >= 0 && count-previous > threshold
+	case "drops by":
+		return previous >= 0 && previous-count > threshold
+	}
+	return count > 0
+}
+
+var relativeTimePattern = regexp.MustCompile(`^-(\d+)(s|sec|m|min|h|hr|d|day|w|week)s?(@.*)?$`)
+
+// ParseInterval parses a slice size such as "15m", "1h" or "-15m@m" (an empty interval returns 0)
+func ParseInterval(interval string) (time.Duration, error) {
+	interval = strings.TrimSpace(interval)
+	if interval == "" {
+		return 0, nil
+	}
+	if !strings.HasPrefix(interval, "-") {
+		interval = "-" + interval
+	}
+	m := relativeTimePattern.FindStringSubmatch(interval)
+	if m == nil {
+		return 0, fmt.Errorf("unsupported interval %q, use e.g. 15m, 1h or 1d", interval)
+	}
+	n, _ := strconv.Atoi(m[1])
+	unit := map[string]time.Duration{
+		"s": time.Second, "sec": time.Second,
+		"m": time.Minute, "min": time.Minute,
+		"h": time.Hour, "hr": time.Hour,
+		"d": 24 * time.Hour, "day": 24 * time.Hour,
+		"w": 7 * 24 * time.Hour, "week": 7 * 24 * time.Hour,
+	}[m[2]]
+	return time.Duration(n) * unit, nil
+}
+
+// scheduleInterval derives the run period of a saved search from simple cron schedules (every N minutes, hourly,
+// every N hours, daily) and the lookback of each run from its relative dispatch window (e.g. -15m), or the period if absent
+func scheduleInterval(earliest, cron string) (step, lookback time.Duration, err error) {
+	step, err = cronPeriod(cron)
+	if err != nil {
+		return 0, 0, err
+	}
+	lookback, err = ParseInterval(earliest)
+	if err != nil || lookback <= 0 {
+		lookback = step
+	}
+	return step, lookback, nil
+}
+
+// cronPeriod returns the period of a cron schedule that fires every N minutes, hourly, every N hours or daily
+func cronPeriod(cron string) (time.Duration, error) {
+	fields := strings.Fields(cron)
+	if len(fields) == 5 && fields[2] == "*" && fields[3] == "*" && fields[4] == "*" {
+		minute, hour := fields[0], fields[1]
+		switch {
+		case strings.HasPrefix(minute, "*/") && hour == "*":
+			if n, err := strconv.Atoi(strings.TrimPrefix(minute, "*/")); err == nil && n > 0 {
+				return time.Duration(n) * time.Minute, nil
+			}
+		case minute == "*" && hour == "*":
+			return time.Minute, nil
+		case hour == "*":
+			return time.Hour, nil
+		case strings.HasPrefix(hour, "*/"):
+			if n, err := strconv.Atoi(strings.TrimPrefix(hour, "*/")); err == nil && n > 0 {
+				return time.Duration(n) * time.Hour, nil
+			}
+		default:
+			if _, err := strconv.Atoi(hour); err == nil {
+				return 24 * time.Hour, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("cannot derive the run period from cron schedule %q, pass interval", cron)
+}
@@ -0,0 +1,158 @@
+package splunk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestConditionMet(t *testing.T) {
+	tests := []struct {
+		name      string
+		condition BacktestCondition
+		count     int
+		previous  int
+		want      bool
+	}{
+		{"always", BacktestCondition{Type: "always"}, 0, -1, true},
+		{"custom with results", BacktestCondition{Type: "custom", Custom: "count > 5"}, 1, -1, true},
+		{"custom without results", BacktestCondition{Type: "custom", Custom: "count > 5"}, 0, -1, false},
+		{"greater than", BacktestCondition{Type: "number of events", Comparator: "greater than", Threshold: "10"}, 11, -1, true},
+		{"greater than at threshold", BacktestCondition{Type: "number of events", Comparator: "greater than", Threshold: "10"}, 10, -1, false},
+		{"less than", BacktestCondition{Type: "number of results", Comparator: "less than", Threshold: "1"}, 0, -1, true},
+		{"equal to", BacktestCondition{Type: "number of results", Comparator: "equal to", Threshold: " 3 "}, 3, -1, true},
+		{"not equal to", BacktestCondition{Type: "number of results", Comparator: "not equal to", Threshold: "3"}, 3, -1, false},
+		{"rises by", BacktestCondition{Type: "number of events", Comparator: "rises by", Threshold: "5"}, 20, 10, true},
+		{"rises by without previous slice", BacktestCondition{Type: "number of events", Comparator: "rises by", Threshold: "5"}, 20, -1, false},
+		{"drops by", BacktestCondition{Type: "number of events", Comparator: "drops by", Threshold: "5"}, 4, 10, true},
+		{"invalid threshold counts as 0", BacktestCondition{Type: "number of events", Comparator: "greater than", Threshold: "many"}, 1, -1, true},
+		{"unknown comparator", BacktestCondition{Type: "number of events", Comparator: "between"}, 0, -1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conditionMet(tt.condition, tt.count, tt.previous); got != tt.want {
+				t.Errorf("conditionMet() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	tests := []struct {
+		interval string
+		want     time.Duration
+		wantErr  bool
+	}{
+		{"", 0, false},
+		{"15m", 15 * time.Minute, false},
+		{"-15m@m", 15 * time.Minute, false},
+		{"1h", time.Hour, false},
+		{"2hr", 2 * time.Hour, false},
+		{"30s", 30 * time.Second, false},
+		{"1d", 24 * time.Hour, false},
+		{"2days", 48 * time.Hour, false},
+		{"1w", 7 * 24 * time.Hour, false},
+		{"now", 0, true},
+		{"-1mon", 0, true},
+		{"15", 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.interval, func(t *testing.T) {
+			got, err := ParseInterval(tt.interval)
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("ParseInterval(%q) = %v, %v, want %v (error %v)", tt.interval, got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestScheduleInterval(t *testing.T) {
+	tests := []struct {
+		name         string
+		earliest     string
+		cron         string
+		want         time.Duration
+		wantLookback time.Duration
+		wantErr      bool
+	}{
+		{"dispatch window is the lookback", "-15m@m", "0 * * * *", time.Hour, 15 * time.Minute, false},
+		{"dispatch window longer than the period", "-60m", "*/5 * * * *", 5 * time.Minute, time.Hour, false},
+		{"every 5 minutes", "", "*/5 * * * *", 5 * time.Minute, 5 * time.Minute, false},
+		{"every minute", "", "* * * * *", time.Minute, time.Minute, false},
+		{"hourly", "", "7 * * * *", time.Hour, time.Hour, false},
+		{"every 4 hours", "", "0 */4 * * *", 4 * time.Hour, 4 * time.Hour, false},
+		{"daily", "", "30 2 * * *", 24 * time.Hour, 24 * time.Hour, false},
+		{"absolute earliest looks back one period", "0", "*/10 * * * *", 10 * time.Minute, 10 * time.Minute, false},
+		{"weekly cron", "-7d", "0 6 * * 1", 0, 0, true},
+		{"nothing to derive from", "", "", 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, lookback, err := scheduleInterval(tt.earliest, tt.cron)
+			if (err != nil) != tt.wantErr || got != tt.want || lookback != tt.wantLookback {
+				t.Errorf("scheduleInterval(%q, %q) = %v, %v, %v, want %v, %v (error %v)", tt.earliest, tt.cron, got, lookback, err, tt.want, tt.wantLookback, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestBacktestDeletesEveryJob(t *testing.T) {
+	var mu sync.Mutex
+	created, deleted := 0, map[string]bool{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/services/search/jobs":
+			created++
+			json.NewEncoder(w).Encode(map[string]string{"sid": fmt.Sprintf("job%d", created)})
+		case r.Method == "DELETE":
+			deleted[strings.TrimPrefix(r.URL.Path, "/services/search/jobs/")] = true
+		case r.Method == "GET":
+			// every other job fails, its cleanup must happen all the same
+			sid := strings.TrimPrefix(r.URL.Path, "/services/search/jobs/")
+			failed := sid[len(sid)-1]%2 == 0
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"entry": []map[string]interface{}{{"name": sid, "content": map[string]interface{}{
+					"isDone": true, "isFailed": failed, "resultCount": 0, "eventCount": 1,
+				}}},
+			})
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "token")
+	report, err := client.Backtest(context.Background(), "index=main", BacktestCondition{}, 1, 3*time.Hour, 6*time.Hour)
+	if err != nil {
+		t.Fatalf("Backtest: %v", err)
+	}
+
+	if report.Runs != 8 || created != 8 {
+		t.Fatalf("expected 8 slices and 8 jobs, got %d slices and %d jobs", report.Runs, created)
+	}
+	for i, slice := range report.Slices {
+		earliest, _ := time.Parse(time.RFC3339, slice.Earliest)
+		latest, _ := time.Parse(time.RFC3339, slice.Latest)
+		if latest.Sub(earliest) != 6*time.Hour {
+			t.Errorf("slice %d covers %v, want the 6h lookback", i, latest.Sub(earliest))
+		}
+		if i == 0 {
+			continue
+		}
+		if previous, _ := time.Parse(time.RFC3339, report.Slices[i-1].Latest); latest.Sub(previous) != 3*time.Hour {
+			t.Errorf("slice %d runs %v after the previous one, want 3h", i, latest.Sub(previous))
+		}
+	}
+	if len(deleted) != created {
+		t.Errorf("expected all %d jobs deleted, got %d", created, len(deleted))
+	}
+	if report.Failed != 4 || report.Triggered != 4 {
+		t.Errorf("expected 4 failed and 4 triggered slices, got %d failed and %d triggered", report.Failed, report.Triggered)
+	}
+}
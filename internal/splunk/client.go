@@ -5,13 +5,20 @@ import (
 	"time"
 )
 
+// DefaultMaxConcurrentJobs is how many async search jobs a client runs at the same time
+const DefaultMaxConcurrentJobs = 4
+
 // Client represents a Splunk client for all tools
 // Credentials are passed directly, not read from environment variables
 // Add HTTP client for reuse and timeouts
+// Audit records write operations; it is only set when write mode is enabled
+// jobSlots limits concurrent async search jobs, so multi-job tools don't exhaust the user's search quota
 type Client struct {
 	BaseURL   string
 	AuthToken string
 	HTTP      *http.Client
+	Audit     *AuditLog
+	jobSlots  chan struct{}
 }
 
 // NewClient creates a new Splunk client using provided credentials
@@ -22,5 +29,6 @@ func NewClient(baseURL, authToken string) *Client {
 		HTTP: &http.Client{
 			Timeout: 30 * time.Second,
 		},
+		jobSlots: make(chan struct{}, DefaultMaxConcurrentJobs),
 	}
 }
@@ -0,0 +1,186 @@
+package splunk
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+)
+
+// freshnessLagSample bounds the events read to compute indexing lag, so the cost doesn't grow with the indexes' volume
+const freshnessLagSample = 100000
+
+// DataFreshness is the last event time and indexing lag (_indextime - _time) of one index/sourcetype.
+// Stale is set when the last event is older than MaxAge, including expected sources that sent nothing at all.
+// LagSampledEvents is the number of events the lag percentiles were computed from.
+type DataFreshness struct {
+	Index            string  `json:"index"`
+	Sourcetype       string  `json:"sourcetype"`
+	Events           int     `json:"events"`
+	LastEvent        string  `json:"last_event,omitempty"`
+	AgeSeconds       int     `json:"age_seconds"`
+	LagSampledEvents int     `json:"lag_sampled_events"`
+	LagP50Seconds    float64 `json:"lag_p50_seconds"`
+	LagP95Seconds    float64 `json:"lag_p95_seconds"`
+	LagMaxSeconds    float64 `json:"lag_max_seconds"`
+	MaxAgeSeconds    int     `json:"max_age_seconds"`
+	ExpectedSource   bool    `json:"expected_source,omitempty"`
+	Stale            bool    `json:"stale"`
+}
+
+// GetDataFreshness computes the last event time per index/sourcetype with tstats and the lag distribution, stalest first.
+// The lag percentiles are taken over the newest freshnessLagSample events of the range, one value per event.
+// maxAge is the default staleness threshold; expected overrides it per "index/sourcetype" (or "index/*") and
+// adds the expected sources that sent no events in the time range.
+func (c *Client) GetDataFreshness(ctx context.Context, index, earliest string, maxAge time.Duration, expected map[string]time.Duration, staleOnly bool, count int) ([]DataFreshness, error) {
+	spl := fmt.Sprintf("| tstats count as events max(_time) as last_event where index=%s earliest=%s latest=now by index sourcetype ", QuoteSPL(index), earliest) +
+		"| eval age=now()-last_event, last_event=strftime(last_event, \"%Y-%m-%dT%H:%M:%S%z\")"
+	rows, err := c.exportSearch(ctx, spl)
+	if err != nil {
+		return nil, err
+	}
+
+	lagSPL := fmt.Sprintf("search index=%s earliest=%s latest=now | head %d ", QuoteSPL(index), earliest, freshnessLagSample) +
+		"| eval lag=_indextime-_time " +
+		"| stats count as sampled perc50(lag) as lag_p50 perc95(lag) as lag_p95 max(lag) as lag_max by index sourcetype"
+	lagRows, err := c.exportSearch(ctx, lagSPL)
+	if err != nil {
+		return nil, err
+	}
+	lags := map[string]map[string]interface{}{}
+	for _, row := range lagRows {
+		lags[getString(row, "index")+"/"+getString(row, "sourcetype")] = row
+	}
+
+	seen := map[string]bool{}
+	var freshness []DataFreshness
+	for _, row := range rows {
+		f := DataFreshness{
+			Index:      getString(row, "index"),
+			Sourcetype: getString(row, "sourcetype"),
+			Events:     getInt(row, "events"),
+			LastEvent:  getString(row, "last_event"),
+			AgeSeconds: getInt(row, "age"),
+		}
+		if lag, ok := lags[f.Index+"/"+f.Sourcetype]; ok {
+			f.LagSampledEvents = getInt(lag, "sampled")
+			f.LagP50Seconds = getFloat(lag, "lag_p50")
+			f.LagP95Seconds = getFloat(lag, "lag_p95")
+			f.LagMaxSeconds = getFloat(lag, "lag_max")
+		}
+		threshold, ok := expected[f.Index+"/"+f.Sourcetype]
+		if !ok {
+			threshold, ok = expected[f.Index+"/*"]
+		}
+		if !ok {
+			threshold = maxAge
+		}
+		f.ExpectedSource = ok
+		f.MaxAgeSeconds = int(threshold.Seconds())
+		f.Stale = f.AgeSeconds > f.MaxAgeSeconds
+		seen[f.Index+"/"+f.Sourcetype] = true
+		freshness = append(freshness, f)
+	}
+
+	// expected sources without any event in the time range
+	for key, threshold := range expected {
+		parts := strings.SplitN(key, "/", 2)
+		if parts[1] == "*" || seen[key] || !matchesIndex(index, parts[0]) {
+			continue
+		}
+		freshness = append(freshness, DataFreshness{
+			Index:          parts[0],
+			Sourcetype:     parts[1],
+			MaxAgeSeconds:  int(threshold.Seconds()),
+			ExpectedSource: true,
+			Stale:          true,
+		})
+	}
+
+	if staleOnly {
+		stale := freshness[:0]
+		for _, f := range freshness {
+			if f.Stale {
+				stale = append(stale, f)
+			}
+		}
+		freshness = stale
+	}
+
+	// stale first, then by how far past the threshold the last event is
+	sort.SliceStable(freshness, func(i, j int) bool {
+		a, b := freshness[i], freshness[j]
+		if a.Stale != b.Stale {
+			return a.Stale
+		}
+		if (a.Events == 0) != (b.Events == 0) {
+			return a.Events == 0
+		}
+		return a.AgeSeconds-a.MaxAgeSeconds > b.AgeSeconds-b.MaxAgeSeconds
+	})
+	if len(freshness) > count {
+		freshness = freshness[:count]
+	}
+	return freshness, nil
+}
+
+// matchesIndex reports whether index matches the index pattern of the search, which may end in a wildcard
+func matchesIndex(pattern, index string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(index, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == index
+}
+
+// ParseFreshnessThresholds reads expected freshness per "index/sourcetype" from the data dictionary CSV.
+// The CSV is semicolon separated; a header row must name the index, sourcetype and expected_freshness columns
+// (freshness like "15m" or "1d"). A dictionary without these columns yields no thresholds.
+func ParseFreshnessThresholds(r io.Reader) (map[string]time.Duration, error) {
+	reader := csv.NewReader(r)
+	reader.Comma = ';'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse data dictionary: %w", err)
+	}
+	thresholds := map[string]time.Duration{}
+	if len(records) == 0 {
+		return thresholds, nil
+	}
+
+	columns := map[string]int{}
+	for i, name := range records[0] {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	indexCol, ok1 := columns["index"]
+	sourcetypeCol, ok2 := columns["sourcetype"]
+	freshnessCol, ok3 := columns["expected_freshness"]
+	if !ok1 || !ok2 || !ok3 {
+		return thresholds, nil
+	}
+
+	for _, record := range records[1:] {
+		if len(record) <= indexCol || len(record) <= sourcetypeCol || len(record) <= freshnessCol {
+			continue
+		}
+		index := strings.TrimSpace(record[indexCol])
+		sourcetype := strings.TrimSpace(record[sourcetypeCol])
+		if index == "" || strings.TrimSpace(record[freshnessCol]) == "" {
+			continue
+		}
+		if sourcetype == "" {
+			sourcetype = "*"
+		}
+		d, err := ParseInterval(record[freshnessCol])
+		if err != nil {
+			return nil, fmt.Errorf("data dictionary entry %s/%s: %w", index, sourcetype, err)
+		}
+		thresholds[index+"/"+sourcetype] = d
+	}
+	return thresholds, nil
+}
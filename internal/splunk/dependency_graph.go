@@ -0,0 +1,403 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// GraphNode is a knowledge object in the dependency graph.
+// Missing is set for objects referenced from SPL that do not exist (or are not visible to the token).
+type GraphNode struct {
+	ID         string   `json:"id"`
+	Type       string   `json:"type"`
+	Name       string   `json:"name"`
+	App        string   `json:"app,omitempty"`
+	Owner      string   `json:"owner,omitempty"`
+	Sharing    string   `json:"sharing,omitempty"`
+	Missing    bool     `json:"missing,omitempty"`
+	DependsOn  []string `json:"depends_on,omitempty"`
+	Dependents []string `json:"dependents,omitempty"`
+}
+
+// DependencyGraph links saved searches, alerts, macros, eventtypes, lookups, automatic lookups and dashboards by the references between them.
+type DependencyGraph struct {
+	Nodes map[string]*GraphNode `json:"nodes"`
+
+	// byName maps type:name to the nodes of that name, one per app (and owner for private objects)
+	byName map[string][]string
+	// lookupFiles maps a lookup table file name to its lookup definition nodes, so `| inputlookup foo.csv` resolves too
+	lookupFiles map[string][]string
+}
+
+// DependencyHit is an object reached while walking the graph; Via is the node it was reached from.
+type DependencyHit struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Name  string `json:"name"`
+	App   string `json:"app,omitempty"`
+	Owner string `json:"owner,omitempty"`
+	Depth int    `json:"depth"`
+	Via   string `json:"via"`
+}
+
+// nodeID is the graph key of an object: type:app/name, or type:app/owner/name for private objects.
+// Missing objects have no app and are keyed type:name.
+func nodeID(objectType, name string, acl restACL) string {
+	switch {
+	case acl.App == "":
+		return objectType + ":" + name
+	case acl.Sharing == "user":
+		return objectType + ":" + acl.App + "/" + acl.Owner + "/" + name
+	}
+	return objectType + ":" + acl.App + "/" + name
+}
+
+func newDependencyGraph() *DependencyGraph {
+	return &DependencyGraph{
+		Nodes:       map[string]*GraphNode{},
+		byName:      map[string][]string{},
+		lookupFiles: map[string][]string{},
+	}
+}
+
+// BuildDependencyGraph fetches all saved searches, macros, eventtypes, lookups, automatic lookups and dashboards and parses their SPL into a graph
+func (c *Client) BuildDependencyGraph(ctx context.Context) (*DependencyGraph, error) {
+	g := newDependencyGraph()
+
+	// Register every object first, so references can be resolved regardless of fetch order
+	sources := map[string][]string{}
+
+	savedSearches, err := c.getAllRESTEntries(ctx, "/servicesNS/-/-/saved/searches")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get saved searches: %w", err)
+	}
+	for _, entry := range savedSearches {
+		t := ObjectSavedSearch
+		if getString(entry.Content, "actions") != "" {
+			t = ObjectAlert
+		}
+		id := g.addNode(t, entry.Name, entry.ACL)
+		sources[id] = append(sources[id], getString(entry.Content, "search"))
+	}
+
+	macros, err := c.getAllMacros(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get macros: %w", err)
+	}
+	for _, macro := range macros {
+		id := g.addNode(ObjectMacro, macro.Name, restACL{App: macro.App, Owner: macro.Owner, Sharing: macro.Sharing})
+		sources[id] = append(sources[id], macro.Definition)
+	}
+
+	eventtypes, err := c.getAllRESTEntries(ctx, "/servicesNS/-/-/saved/eventtypes")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get eventtypes: %w", err)
+	}
+	for _, entry := range eventtypes {
+		id := g.addNode(ObjectEventtype, entry.Name, entry.ACL)
+		sources[id] = append(sources[id], getString(entry.Content, "search"))
+	}
+
+	lookups, err := c.getAllRESTEntries(ctx, "/servicesNS/-/-/data/transforms/lookups")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get lookups: %w", err)
+	}
+	for _, entry := range lookups {
+		id := g.addNode(ObjectLookup, entry.Name, entry.ACL)
+		if filename := getString(entry.Content, "filename"); filename != "" {
+			g.lookupFiles[filename] = append(g.lookupFiles[filename], id)
+		}
+	}
+
+	refs := map[string][]objectRef{}
+
+	autoLookups, err := c.getAllRESTEntries(ctx, "/servicesNS/-/-/data/props/lookups")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get automatic lookups: %w", err)
+	}
+	for _, entry := range autoLookups {
+		id := g.addNode(ObjectAutoLookup, entry.Name, entry.ACL)
+		if transform := getString(entry.Content, "transform"); transform != "" {
+			refs[id] = append(refs[id], objectRef{Type: ObjectLookup, Name: transform})
+		}
+	}
+
+	dashboards, err := c.getAllRESTEntries(ctx, "/servicesNS/-/-/data/ui/views")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get dashboards: %w", err)
+	}
+	for _, entry := range dashboards {
+		id := g.addNode(ObjectDashboard, entry.Name, entry.ACL)
+		queries, savedSearchRefs := dashboardReferences(getString(entry.Content, "eai:data"))
+		sources[id] = append(sources[id], queries...)
+		for _, name := range savedSearchRefs {
+			refs[id] = append(refs[id], objectRef{Type: ObjectSavedSearch, Name: name})
+		}
+	}
+
+	g.link(sources, refs)
+	return g, nil
+}
+
+func (g *DependencyGraph) addNode(objectType, name string, acl restACL) string {
+	id := nodeID(objectType, name, acl)
+	if _, ok := g.Nodes[id]; !ok {
+		g.Nodes[id] = &GraphNode{ID: id, Type: objectType, Name: name, App: acl.App, Owner: acl.Owner, Sharing: acl.Sharing, Missing: acl.App == ""}
+		g.byName[objectType+":"+name] = append(g.byName[objectType+":"+name], id)
+	}
+	return id
+}
+
+// link turns the references in the SPL sources and the references made outside SPL (saved searches of dashboards,
+// lookups of automatic lookups) into edges, resolved from the referencing node
+func (g *DependencyGraph) link(sources map[string][]string, refs map[string][]objectRef) {
+	for id, spls := range sources {
+		from := g.Nodes[id]
+		for _, spl := range spls {
+			for _, ref := range splReferences(spl) {
+				g.addEdge(id, g.resolve(from, ref))
+			}
+		}
+	}
+	for id, objects := range refs {
+		from := g.Nodes[id]
+		for _, ref := range objects {
+			g.addEdge(id, g.resolve(from, ref))
+		}
+	}
+
+	for _, node := range g.Nodes {
+		sort.Strings(node.DependsOn)
+		sort.Strings(node.Dependents)
+	}
+}
+
+// named returns the nodes called name of a type; saved searches and alerts are interchangeable, since SPL and callers cannot tell them apart
+func (g *DependencyGraph) named(objectType, name string) []string {
+	switch objectType {
+	case ObjectSavedSearch, ObjectAlert:
+		return append(append([]string{}, g.byName[ObjectAlert+":"+name]...), g.byName[ObjectSavedSearch+":"+name]...)
+	}
+	return g.byName[objectType+":"+name]
+}
+
+// resolve maps a reference made from a node to the object Splunk would use, creating a missing node when none is visible.
+// A `loadjob savedsearch="owner:app:name"` reference is resolved from the app and owner it names.
+func (g *DependencyGraph) resolve(from *GraphNode, ref objectRef) string {
+	app, owner := from.App, from.Owner
+	if ref.App != "" {
+		app, owner = ref.App, ref.Owner
+	}
+
+	id := g.pick(g.named(ref.Type, ref.Name), app, owner)
+	if id == "" && ref.Type == ObjectLookup {
+		id = g.pick(g.lookupFiles[ref.Name], app, owner)
+	}
+	if id != "" {
+		return id
+	}
+	return g.addNode(ref.Type, ref.Name, restACL{})
+}
+
+// pick chooses among same-named objects the one a search running in app as owner sees, in Splunk's order:
+// the owner's private object in the app, then the object shared in the app, then a globally shared object from another app.
+// It returns "" when none is visible.
+func (g *DependencyGraph) pick(ids []string, app, owner string) string {
+	best, bestRank := "", 0
+	for _, id := range ids {
+		node := g.Nodes[id]
+		rank := 0
+		switch {
+		case node.Missing:
+		case node.App == app && node.Sharing == "user":
+			if node.Owner == owner {
+				rank = 3
+			}
+		case node.App == app:
+			rank = 2
+		case node.Sharing == "global":
+			rank = 1
+		}
+		// several global objects of the same name are ordered by ID, so the choice is stable
+		if rank > bestRank || (rank > 0 && rank == bestRank && id < best) {
+			best, bestRank = id, rank
+		}
+	}
+	return best
+}
+
+func (g *DependencyGraph) addEdge(from, to string) {
+	if from == to {
+		return
+	}
+	for _, existing := range g.Nodes[from].DependsOn {
+		if existing == to {
+			return
+		}
+	}
+	g.Nodes[from].DependsOn = append(g.Nodes[from].DependsOn, to)
+	g.Nodes[to].Dependents = append(g.Nodes[to].Dependents, from)
+}
+
+// Dependents walks the objects that (transitively) depend on the given object, up to maxDepth levels (0 means unlimited)
+func (g *DependencyGraph) Dependents(objectType, name, app, owner string, maxDepth int) ([]DependencyHit, error) {
+	id, err := g.find(objectType, name, app, owner)
+	if err != nil {
+		return nil, err
+	}
+	return g.walk(id, maxDepth, func(n *GraphNode) []string { return n.Dependents })
+}
+
+// Dependencies walks the objects the given object (transitively) depends on, up to maxDepth levels (0 means unlimited)
+func (g *DependencyGraph) Dependencies(objectType, name, app, owner string, maxDepth int) ([]DependencyHit, error) {
+	id, err := g.find(objectType, name, app, owner)
+	if err != nil {
+		return nil, err
+	}
+	return g.walk(id, maxDepth, func(n *GraphNode) []string { return n.DependsOn })
+}
+
+// find returns the node ID of an object, optionally narrowed by app and owner; it fails when the name is ambiguous
+func (g *DependencyGraph) find(objectType, name, app, owner string) (string, error) {
+	var ids []string
+	for _, id := range g.named(objectType, name) {
+		node := g.Nodes[id]
+		if (app == "" || node.App == app) && (owner == "" || node.Owner == owner) {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) > 1 && app != "" {
+		// within one app, the owner's private object shadows the shared one
+		if id := g.pick(ids, app, owner); id != "" {
+			return id, nil
+		}
+	}
+	switch len(ids) {
+	case 0:
+		return "", fmt.Errorf("%s %q not found", objectType, name)
+	case 1:
+		return ids[0], nil
+	}
+	sort.Strings(ids)
+	return "", fmt.Errorf("%s %q is ambiguous, pass app (and owner) to pick one of: %s", objectType, name, strings.Join(ids, ", "))
+}
+
+// walk is a breadth-first traversal, so every object is reported at its shortest distance from the start
+func (g *DependencyGraph) walk(start string, maxDepth int, next func(*GraphNode) []string) ([]DependencyHit, error) {
+	if _, ok := g.Nodes[start]; !ok {
+		return nil, fmt.Errorf("object %q not found", start)
+	}
+
+	hits := []DependencyHit{}
+	visited := map[string]bool{start: true}
+	queue := []DependencyHit{{ID: start}}
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		if maxDepth > 0 && current.Depth >= maxDepth {
+			continue
+		}
+		for _, id := range next(g.Nodes[current.ID]) {
+			if visited[id] {
+				continue
+			}
+			visited[id] = true
+			node := g.Nodes[id]
+			hit := DependencyHit{
+				ID:    id,
+				Type:  node.Type,
+				Name:  node.Name,
+				App:   node.App,
+				Owner: node.Owner,
+				Depth: current.Depth + 1,
+				Via:   current.ID,
+			}
+			hits = append(hits, hit)
+			queue = append(queue, hit)
+		}
+	}
+	return hits, nil
+}
+
+// Export renders the graph as Graphviz DOT ("dot") or a Mermaid flowchart ("mermaid").
+// With a root object only the root, its dependencies and its dependents are included; edges point from an object to what it depends on.
+func (g *DependencyGraph) Export(format, rootType, rootName, rootApp, rootOwner string) (string, error) {
+	include := map[string]bool{}
+	if rootName != "" {
+		root, err := g.find(rootType, rootName, rootApp, rootOwner)
+		if err != nil {
+			return "", err
+		}
+		dependencies, err := g.walk(root, 0, func(n *GraphNode) []string { return n.DependsOn })
+		if err != nil {
+			return "", err
+		}
+		dependents, _ := g.walk(root, 0, func(n *GraphNode) []string { return n.Dependents })
+		include[root] = true
+		for _, hit := range append(dependencies, dependents...) {
+			include[hit.ID] = true
+		}
+	} else {
+		for id := range g.Nodes {
+			include[id] = true
+		}
+	}
+
+	ids := make([]string, 0, len(include))
+	for id := range include {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	var b strings.Builder
+	switch format {
+	case "dot":
+		b.WriteString("digraph splunk_dependencies {\n  rankdir=LR;\n")
+		for _, id := range ids {
+			style := ""
+			if g.Nodes[id].Missing {
+				style = ", style=dashed"
+			}
+			b.WriteString(fmt.Sprintf("  %s [label=%s%s];\n", dotQuote(id), dotQuote(id), style))
+		}
+		for _, id := range ids {
+			for _, dep := range g.Nodes[id].DependsOn {
+				if include[dep] {
+					b.WriteString(fmt.Sprintf("  %s -> %s;\n", dotQuote(id), dotQuote(dep)))
+				}
+			}
+		}
+		b.WriteString("}\n")
+	case "mermaid":
+		// Mermaid node IDs must be plain identifiers, object names go into the labels
+		alias := map[string]string{}
+		b.WriteString("flowchart LR\n")
+		for i, id := range ids {
+			alias[id] = fmt.Sprintf("n%d", i)
+			label := strings.ReplaceAll(id, "\"", "#quot;")
+			if g.Nodes[id].Missing {
+				label += " (missing)"
+			}
+			b.WriteString(fmt.Sprintf("  %s[\"%s\"]\n", alias[id], label))
+		}
+		for _, id := range ids {
+			for _, dep := range g.Nodes[id].DependsOn {
+				if include[dep] {
+					b.WriteString(fmt.Sprintf("  %s --> %s\n", alias[id], alias[dep]))
+				}
+			}
+		}
+	default:
+		return "", fmt.Errorf("unsupported format %q, use dot or mermaid", format)
+	}
+	return b.String(), nil
+}
+
+var dotQuoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// dotQuote quotes a DOT ID; only quotes and backslashes are escaped, other characters (including non-ASCII) are written as UTF-8
+func dotQuote(v string) string {
+	return `"` + dotQuoteEscaper.Replace(v) + `"`
+}
@@ -0,0 +1,123 @@
+package splunk
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDependencyGraphResolvesPrivateThenAppThenGlobal(t *testing.T) {
+	g := newDependencyGraph()
+	private := g.addNode(ObjectMacro, "base", restACL{App: "sec", Owner: "alice", Sharing: "user"})
+	inApp := g.addNode(ObjectMacro, "base", restACL{App: "sec", Owner: "nobody", Sharing: "app"})
+	global := g.addNode(ObjectMacro, "base", restACL{App: "common", Owner: "nobody", Sharing: "global"})
+	g.addNode(ObjectMacro, "base", restACL{App: "ops", Owner: "nobody", Sharing: "app"})
+
+	sources := map[string][]string{}
+	searches := map[string]restACL{
+		"alice in sec": {App: "sec", Owner: "alice", Sharing: "user"},
+		"bob in sec":   {App: "sec", Owner: "bob", Sharing: "user"},
+		"bob in web":   {App: "web", Owner: "bob", Sharing: "app"},
+		"bob in hr":    {App: "hr", Owner: "bob", Sharing: "app"},
+	}
+	ids := map[string]string{}
+	for name, acl := range searches {
+		ids[name] = g.addNode(ObjectSavedSearch, name, acl)
+		sources[ids[name]] = []string{"`base` | stats count"}
+	}
+	g.addNode(ObjectMacro, "base", restACL{App: "hr", Owner: "carol", Sharing: "user"})
+	g.link(sources, nil)
+
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{"alice in sec", private},
+		{"bob in sec", inApp},
+		{"bob in web", global},
+		// carol's private macro in hr is not visible to bob
+		{"bob in hr", global},
+	}
+	for _, tt := range tests {
+		t.Run(tt.search, func(t *testing.T) {
+			if got := g.Nodes[ids[tt.search]].DependsOn; len(got) != 1 || got[0] != tt.want {
+				t.Errorf("DependsOn = %v, want [%s]", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDependencyGraphMissingAndLoadjobReferences(t *testing.T) {
+	g := newDependencyGraph()
+	report := g.addNode(ObjectSavedSearch, "report", restACL{App: "ops", Owner: "nobody", Sharing: "app"})
+	g.addNode(ObjectSavedSearch, "report", restACL{App: "sec", Owner: "nobody", Sharing: "app"})
+	lookup := g.addNode(ObjectLookup, "assets", restACL{App: "ops", Owner: "nobody", Sharing: "app"})
+	g.lookupFiles["assets.csv"] = []string{lookup}
+	search := g.addNode(ObjectSavedSearch, "summary", restACL{App: "web", Owner: "bob", Sharing: "app"})
+	g.link(map[string][]string{
+		search: {"| loadjob savedsearch=\"nobody:ops:report\" | lookup assets.csv host"},
+	}, nil)
+
+	want := []string{"lookup:assets.csv", report}
+	if got := g.Nodes[search].DependsOn; strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("DependsOn = %v, want %v", got, want)
+	}
+	if !g.Nodes["lookup:assets.csv"].Missing {
+		t.Errorf("lookup shared only in ops resolved from web, want a missing node")
+	}
+}
+
+func TestDependencyGraphFind(t *testing.T) {
+	g := newDependencyGraph()
+	g.addNode(ObjectMacro, "base", restACL{App: "sec", Owner: "nobody", Sharing: "app"})
+	private := g.addNode(ObjectMacro, "base", restACL{App: "sec", Owner: "alice", Sharing: "user"})
+	ops := g.addNode(ObjectMacro, "base", restACL{App: "ops", Owner: "nobody", Sharing: "app"})
+	alert := g.addNode(ObjectAlert, "failed logins", restACL{App: "sec", Owner: "nobody", Sharing: "app"})
+
+	tests := []struct {
+		name       string
+		objectType string
+		object     string
+		app, owner string
+		want       string
+		wantErr    string
+	}{
+		{"ambiguous without app", ObjectMacro, "base", "", "", "", "ambiguous"},
+		{"single match in app", ObjectMacro, "base", "ops", "", ops, ""},
+		{"private shadows app level", ObjectMacro, "base", "sec", "alice", private, ""},
+		{"saved search finds alert", ObjectSavedSearch, "failed logins", "", "", alert, ""},
+		{"not found", ObjectMacro, "other", "", "", "", "not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := g.find(tt.objectType, tt.object, tt.app, tt.owner)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("find() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Errorf("find() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportDOTKeepsUTF8(t *testing.T) {
+	g := newDependencyGraph()
+	search := g.addNode(ObjectSavedSearch, `Échecs "VPN" \ nuit`, restACL{App: "sécurité", Owner: "nobody", Sharing: "app"})
+	g.link(map[string][]string{search: {"`réseau`"}}, nil)
+
+	out, err := g.Export("dot", "", "", "", "")
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+	for _, want := range []string{
+		`"savedsearch:sécurité/Échecs \"VPN\" \\ nuit" [label="savedsearch:sécurité/Échecs \"VPN\" \\ nuit"];`,
+		`"savedsearch:sécurité/Échecs \"VPN\" \\ nuit" -> "macro:réseau";`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Export() is missing %s in\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"math"
+)
+
+// EventPattern is one group of similar events with its size and one example event.
+type EventPattern struct {
+	Count      int     `json:"count"`
+	Percent    float64 `json:"percent"`
+	Punct      string  `json:"punct,omitempty"`
+	Sourcetype string  `json:"sourcetype"`
+	Example    string  `json:"example"`
+	FirstSeen  string  `json:"first_seen,omitempty"`
+	LastSeen   string  `json:"last_seen,omitempty"`
+}
+
+// EventPatternReport is the top patterns of a sample of a search's events, largest first.
+type EventPatternReport struct {
+	Search   string         `json:"search"`
+	Method   string         `json:"method"`
+	Events   int            `json:"events"`
+	Sampled  bool           `json:"sampled"`
+	Patterns []EventPattern `json:"patterns"`
+}
+
+// GetEventPatterns groups the first sample events of a search into patterns and returns the count largest with one example each.
+// method "cluster" groups by _raw similarity (the cluster command, threshold 0-1, higher is stricter); "punct" groups by punctuation pattern.
+// Examples are truncated to exampleLength runes.
+func (c *Client) GetEventPatterns(ctx context.Context, spl, earliest, latest, method string, threshold float64, sample, count, exampleLength int) (*EventPatternReport, error) {
+	job := fmt.Sprintf("%s | head %d | eventstats count as mcp_total ", searchCommand(spl), sample)
+	switch method {
+	case "cluster":
+		job += fmt.Sprintf("| cluster showcount=true t=%g field=_raw | rename cluster_count as count ", threshold)
+	case "punct":
+		job += "| stats count, first(_raw) as _raw, first(sourcetype) as sourcetype, first(mcp_total) as mcp_total, min(_time) as first_seen, max(_time) as last_seen by punct " +
+			"| eval first_seen=strftime(first_seen, \"%Y-%m-%dT%H:%M:%S%z\"), last_seen=strftime(last_seen, \"%Y-%m-%dT%H:%M:%S%z\") "
+	default:
+		return nil, fmt.Errorf("unsupported method %q, use cluster or punct", method)
+	}
+	job += fmt.Sprintf("| sort 0 - count | head %d | table count mcp_total punct sourcetype first_seen last_seen _raw", count)
+
+	_, rows, err := c.RunJob(ctx, job, earliest, latest, count)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &EventPatternReport{Search: spl, Method: method, Patterns: []EventPattern{}}
+	for _, row := range rows {
+		report.Events = getInt(row, "mcp_total")
+		pattern := EventPattern{
+			Count:      getInt(row, "count"),
+			Punct:      getString(row, "punct"),
+			Sourcetype: getString(row, "sourcetype"),
+			Example:    truncate(getString(row, "_raw"), exampleLength),
+			FirstSeen:  getString(row, "first_seen"),
+			LastSeen:   getString(row, "last_seen"),
+		}
+		report.Patterns = append(report.Patterns, pattern)
+	}
+	for i := range report.Patterns {
+		if report.Events > 0 {
+			report.Patterns[i].Percent = math.Round(float64(report.Patterns[i].Count)/float64(report.Events)*1000) / 10
+		}
+	}
+	report.Sampled = report.Events >= sample
+	return report, nil
+}
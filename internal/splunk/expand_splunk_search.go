@@ -0,0 +1,285 @@
+package splunk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// maxMacroDepth bounds recursive expansion in case a cycle is not caught by name (e.g. argument-built macro names)
+const maxMacroDepth = 50
+
+// MacroExpansion is a node of the expansion tree: one macro call and the macros its definition references.
+type MacroExpansion struct {
+	Call     string            `json:"call"`
+	Macro    string            `json:"macro"`
+	App      string            `json:"app,omitempty"`
+	Args     map[string]string `json:"args,omitempty"`
+	Expanded string            `json:"expanded"`
+	Children []*MacroExpansion `json:"children,omitempty"`
+}
+
+// SearchExpansion is the fully expanded SPL of a search together with its expansion tree.
+type SearchExpansion struct {
+	Search       string                 `json:"search"`
+	Expanded     string                 `json:"expanded"`
+	Tree         []*MacroExpansion      `json:"tree"`
+	Unresolved   []string               `json:"unresolved,omitempty"`
+	SplunkParser map[string]interface{} `json:"splunk_parser,omitempty"`
+}
+
+// ExpandSearch fetches all macros and recursively expands the macros referenced in spl.
+// app selects which definition wins when the same macro exists in several apps.
+// With crossCheck, Splunk's own /services/search/parser result is attached for comparison.
+func (c *Client) ExpandSearch(ctx context.Context, spl, app string, crossCheck bool) (*SearchExpansion, error) {
+	macros, err := c.getAllMacros(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get macros: %w", err)
+	}
+
+	expansion, err := ExpandMacros(spl, macros, app)
+	if err != nil {
+		return nil, err
+	}
+
+	if crossCheck {
+		parsed, err := c.parseSearch(ctx, spl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to cross-check with search parser: %w", err)
+		}
+		expansion.SplunkParser = parsed
+	}
+
+	return expansion, nil
+}
+
+// ExpandMacros recursively substitutes `macro` and `macro(arg1,arg2)` references in spl using the given macros.
+// Argumented macros are looked up by Splunk's `name(N)` naming and their $arg$ tokens are bound by position
+// from the macro's args definition. Unknown macros are left in place and reported as unresolved.
+func ExpandMacros(spl string, macros []Macro, app string) (*SearchExpansion, error) {
+	e := &macroExpander{
+		macros:     indexMacros(macros, app),
+		unresolved: map[string]bool{},
+	}
+
+	expanded, tree, err := e.expand(spl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SearchExpansion{
+		Search:   spl,
+		Expanded: expanded,
+		Tree:     tree,
+	}
+	for name := range e.unresolved {
+		result.Unresolved = append(result.Unresolved, name)
+	}
+	sort.Strings(result.Unresolved)
+	return result, nil
+}
+
+// indexMacros keys macros by name, preferring the definition from app, then globally shared ones
+func indexMacros(macros []Macro, app string) map[string]Macro {
+	rank := func(m Macro) int {
+		switch {
+		case app != "" && m.App == app:
+			return 2
+		case m.Sharing == "global":
+			return 1
+		}
+		return 0
+	}
+
+	index := map[string]Macro{}
+	for _, m := range macros {
+		if m.Disabled {
+			continue
+		}
+		if current, ok := index[m.Name]; !ok || rank(m) > rank(current) {
+			index[m.Name] = m
+		}
+	}
+	return index
+}
+
+type macroExpander struct {
+	macros     map[string]Macro
+	unresolved map[string]bool
+}
+
+// expand replaces every backtick-quoted macro call in spl; stack holds the macros being expanded for cycle detection
+func (e *macroExpander) expand(spl string, stack []string) (string, []*MacroExpansion, error) {
+	if len(stack) > maxMacroDepth {
+		return "", nil, fmt.Errorf("macro expansion exceeded depth %d: %s", maxMacroDepth, strings.Join(stack, " -> "))
+	}
+
+	var b strings.Builder
+	var nodes []*MacroExpansion
+	rest := spl
+	for {
+		start := strings.IndexByte(rest, '`')
+		if start < 0 {
+			b.WriteString(rest)
+			break
+		}
+		end := strings.IndexByte(rest[start+1:], '`')
+		if end < 0 {
+			// Unbalanced backtick, keep the remainder verbatim
+			b.WriteString(rest)
+			break
+		}
+		end += start + 1
+
+		b.WriteString(rest[:start])
+		call := rest[start+1 : end]
+		rest = rest[end+1:]
+
+		name, args := parseMacroCall(call)
+		key := name
+		if args != nil {
+			key = fmt.Sprintf("%s(%d)", name, len(args))
+		}
+
+		macro, ok := e.macros[key]
+		if !ok {
+			e.unresolved[key] = true
+			b.WriteString("`" + call + "`")
+			continue
+		}
+
+		for _, seen := range stack {
+			if seen == key {
+				return "", nil, fmt.Errorf("macro cycle detected: %s -> %s", strings.Join(stack, " -> "), key)
+			}
+		}
+
+		node := &MacroExpansion{
+			Call:  "`" + call + "`",
+			Macro: key,
+			App:   macro.App,
+		}
+		definition := macro.Definition
+		if args != nil {
+			names := splitMacroArgs(macro.Args)
+			if len(names) != len(args) {
+				return "", nil, fmt.Errorf("macro %s declares %d arguments but was called with %d", key, len(names), len(args))
+			}
+			node.Args = map[string]string{}
+			var pairs []string
+			for i, argName := range names {
+				node.Args[argName] = args[i]
+				pairs = append(pairs, "$"+argName+"$", args[i])
+			}
+			// one pass over the definition, so a value containing $other$ is not substituted again
+			definition = strings.NewReplacer(pairs...).Replace(definition)
+		}
+
+		expanded, children, err := e.expand(definition, append(stack, key))
+		if err != nil {
+			return "", nil, err
+		}
+		node.Expanded = expanded
+		node.Children = children
+		nodes = append(nodes, node)
+		b.WriteString(expanded)
+	}
+
+	return b.String(), nodes, nil
+}
+
+// parseMacroCall splits `name(a, b)` into its name and arguments. args is nil for a call without parentheses.
+func parseMacroCall(call string) (string, []string) {
+	call = strings.TrimSpace(call)
+	open := strings.IndexByte(call, '(')
+	if open < 0 || !strings.HasSuffix(call, ")") {
+		return call, nil
+	}
+	name := strings.TrimSpace(call[:open])
+	inner := call[open+1 : len(call)-1]
+	if strings.TrimSpace(inner) == "" {
+		return name, []string{}
+	}
+	return name, splitMacroArgs(inner)
+}
+
+// splitMacroArgs splits a comma separated argument list, ignoring commas inside quotes and parentheses
+func splitMacroArgs(s string) []string {
+	if strings.TrimSpace(s) == "" {
+		return nil
+	}
+	var args []string
+	var current strings.Builder
+	depth := 0
+	inQuotes := false
+	for i := 0; i < len(s); i++ {
+		ch := s[i]
+		switch {
+		case ch == '\\' && inQuotes && i+1 < len(s):
+			current.WriteByte(ch)
+			i++
+			ch = s[i]
+		case ch == '"':
+			inQuotes = !inQuotes
+		case ch == '(' && !inQuotes:
+			depth++
+		case ch == ')' && !inQuotes:
+			depth--
+		case ch == ',' && !inQuotes && depth == 0:
+			args = append(args, strings.TrimSpace(current.String()))
+			current.Reset()
+			continue
+		}
+		current.WriteByte(ch)
+	}
+	return append(args, strings.TrimSpace(current.String()))
+}
+
+// parseSearch asks Splunk's search parser to parse spl, which expands macros server side
+func (c *Client) parseSearch(ctx context.Context, spl string) (map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("%s/services/search/parser", c.BaseURL)
+	form := url.Values{}
+	form.Set("q", spl)
+	form.Set("parse_only", "false")
+	form.Set("output_mode", "json")
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AuthToken))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result, nil
+}
+
+// parsedCommands returns the command names of a /services/search/parser result in pipeline order, with macros expanded
+func parsedCommands(parsed map[string]interface{}) []string {
+	commands, _ := parsed["commands"].([]interface{})
+	var names []string
+	for _, command := range commands {
+		if m, ok := command.(map[string]interface{}); ok {
+			names = append(names, getString(m, "command"))
+		}
+	}
+	return names
+}
@@ -0,0 +1,113 @@
+package splunk
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseMacroCall(t *testing.T) {
+	tests := []struct {
+		call     string
+		wantName string
+		wantArgs []string
+	}{
+		{"base", "base", nil},
+		{" base ", "base", nil},
+		{"noargs()", "noargs", []string{}},
+		{"filter(main, web)", "filter", []string{"main", "web"}},
+		{`filter("a,b", c)`, "filter", []string{`"a,b"`, "c"}},
+		{`filter("say \"hi, there\"", c)`, "filter", []string{`"say \"hi, there\""`, "c"}},
+		{"filter(coalesce(a, b), c)", "filter", []string{"coalesce(a, b)", "c"}},
+		{"broken(a", "broken(a", nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.call, func(t *testing.T) {
+			name, args := parseMacroCall(tt.call)
+			if name != tt.wantName || !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("parseMacroCall(%q) = %q, %q, want %q, %q", tt.call, name, args, tt.wantName, tt.wantArgs)
+			}
+		})
+	}
+}
+
+func TestSplitMacroArgs(t *testing.T) {
+	tests := []struct {
+		args string
+		want []string
+	}{
+		{"", nil},
+		{"index", []string{"index"}},
+		{"index, sourcetype", []string{"index", "sourcetype"}},
+		{`"a, b",c`, []string{`"a, b"`, "c"}},
+		{"if(x>1, 1, 0), y", []string{"if(x>1, 1, 0)", "y"}},
+		{"a,,b", []string{"a", "", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.args, func(t *testing.T) {
+			if got := splitMacroArgs(tt.args); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("splitMacroArgs(%q) = %q, want %q", tt.args, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExpandMacros(t *testing.T) {
+	macros := []Macro{
+		{Name: "base", Definition: "index=main `web_sourcetypes`"},
+		{Name: "web_sourcetypes", Definition: `(sourcetype="access_*" OR sourcetype=nginx)`},
+		{Name: "by_status(2)", Definition: "status=$code$ host=$host$", Args: "code, host"},
+		{Name: "wrap(1)", Definition: "`by_status($s$, web01)`", Args: "s"},
+		{Name: "loop_a", Definition: "`loop_b`"},
+		{Name: "loop_b", Definition: "x `loop_a`"},
+		{Name: "pick", Definition: "app=ops", ACL: ACL{App: "ops"}},
+		{Name: "pick", Definition: "app=sec", ACL: ACL{App: "sec"}},
+		{Name: "pick", Definition: "app=common", ACL: ACL{App: "common", Sharing: "global"}},
+	}
+
+	tests := []struct {
+		name           string
+		spl            string
+		app            string
+		want           string
+		wantUnresolved []string
+		wantErr        string
+	}{
+		{"nested", "`base` | stats count", "", `index=main (sourcetype="access_*" OR sourcetype=nginx) | stats count`, nil, ""},
+		{"arguments by position", "`by_status(500, \"web, 01\")`", "", `status=500 host="web, 01"`, nil, ""},
+		{"arguments through a nested macro", "`wrap(404)`", "", "status=404 host=web01", nil, ""},
+		{"unknown macro is kept", "`missing` `by_status(1)`", "", "`missing` `by_status(1)`", []string{"by_status(1)", "missing"}, ""},
+		{"app definition wins", "`pick`", "sec", "app=sec", nil, ""},
+		{"global definition outside its app", "`pick`", "web", "app=common", nil, ""},
+		{"cycle", "`loop_a`", "", "", nil, "macro cycle detected: loop_a -> loop_b -> loop_a"},
+		{"argument count mismatch", "`wrap(1, 2)`", "", "`wrap(1, 2)`", []string{"wrap(2)"}, ""},
+		{"argument values are not substituted again", "`by_status($host$, web01)`", "", "status=$host$ host=web01", nil, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ExpandMacros(tt.spl, macros, tt.app)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("ExpandMacros() error = %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ExpandMacros() error = %v", err)
+			}
+			if got.Expanded != tt.want {
+				t.Errorf("Expanded = %s, want %s", got.Expanded, tt.want)
+			}
+			if !reflect.DeepEqual(got.Unresolved, tt.wantUnresolved) {
+				t.Errorf("Unresolved = %q, want %q", got.Unresolved, tt.wantUnresolved)
+			}
+		})
+	}
+}
+
+func TestExpandMacrosRejectsWrongArgumentDefinition(t *testing.T) {
+	macros := []Macro{{Name: "bad(2)", Definition: "$a$", Args: "a"}}
+	if _, err := ExpandMacros("`bad(1, 2)`", macros, ""); err == nil || !strings.Contains(err.Error(), "declares 1 arguments but was called with 2") {
+		t.Errorf("ExpandMacros() error = %v, want an argument count error", err)
+	}
+}
@@ -0,0 +1,100 @@
+package splunk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+)
+
+// fieldSummaryMarker is a field added to every event so its fieldsummary count is the number of summarized events
+const fieldSummaryMarker = "mcp_summary_event"
+
+// maxFieldValueLength truncates long top values (e.g. _raw-like fields) so the summary fits in context
+const maxFieldValueLength = 100
+
+// maxSummaryFields bounds the fieldsummary rows fetched from the job, one per field
+const maxSummaryFields = 1000
+
+// FieldValue is one of the most frequent values of a field.
+type FieldValue struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+// FieldSummary describes one field of a search: how many events have it, its distinct and top values and, for numeric fields, min/max/mean.
+type FieldSummary struct {
+	Field         string       `json:"field"`
+	Count         int          `json:"count"`
+	CoveragePct   float64      `json:"coverage_pct"`
+	DistinctCount int          `json:"distinct_count"`
+	IsExact       bool         `json:"is_exact"`
+	Numeric       bool         `json:"numeric"`
+	Min           *float64     `json:"min,omitempty"`
+	Max           *float64     `json:"max,omitempty"`
+	Mean          *float64     `json:"mean,omitempty"`
+	TopValues     []FieldValue `json:"top_values"`
+}
+
+// FieldSummaryReport is the field summary of a sample of a search's events, most common fields first.
+type FieldSummaryReport struct {
+	Search  string         `json:"search"`
+	Events  int            `json:"events"`
+	Sampled bool           `json:"sampled"`
+	Fields  []FieldSummary `json:"fields"`
+	Omitted int            `json:"omitted_fields"`
+}
+
+// GetFieldSummary runs `| fieldsummary` over the first sample events of a search and returns up to maxFields fields
+// with at most maxValues top values each, most common fields first
+func (c *Client) GetFieldSummary(ctx context.Context, spl, earliest, latest string, sample, maxFields, maxValues int) (*FieldSummaryReport, error) {
+	job := fmt.Sprintf("%s | head %d | eval %s=1 | fieldsummary maxvals=%d", searchCommand(spl), sample, fieldSummaryMarker, maxValues)
+	_, rows, err := c.RunJob(ctx, job, earliest, latest, maxSummaryFields)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &FieldSummaryReport{Search: spl, Fields: []FieldSummary{}}
+	for _, row := range rows {
+		name := getString(row, "field")
+		if name == fieldSummaryMarker {
+			report.Events = getInt(row, "count")
+			continue
+		}
+
+		field := FieldSummary{
+			Field:         name,
+			Count:         getInt(row, "count"),
+			DistinctCount: getInt(row, "distinct_count"),
+			IsExact:       getBool(row, "is_exact"),
+			Numeric:       getInt(row, "numeric_count") > 0 && getInt(row, "numeric_count") == getInt(row, "count"),
+			TopValues:     []FieldValue{},
+		}
+		if field.Numeric {
+			min, max, mean := getFloat(row, "min"), getFloat(row, "max"), getFloat(row, "mean")
+			field.Min, field.Max, field.Mean = &min, &max, &mean
+		}
+		var values []FieldValue
+		if err := json.Unmarshal([]byte(getString(row, "values")), &values); err == nil {
+			for _, v := range values {
+				v.Value = truncate(v.Value, maxFieldValueLength)
+				field.TopValues = append(field.TopValues, v)
+			}
+		}
+		report.Fields = append(report.Fields, field)
+	}
+
+	report.Sampled = report.Events >= sample
+	for i := range report.Fields {
+		if report.Events > 0 {
+			report.Fields[i].CoveragePct = math.Round(float64(report.Fields[i].Count)/float64(report.Events)*1000) / 10
+		}
+	}
+	sort.SliceStable(report.Fields, func(i, j int) bool { return report.Fields[i].Count > report.Fields[j].Count })
+	if len(report.Fields) > maxFields {
+		report.Omitted = len(report.Fields) - maxFields
+		report.Fields = report.Fields[:maxFields]
+	}
+	return report, nil
+}
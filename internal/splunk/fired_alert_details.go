@@ -0,0 +1,196 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TriggeredAlert is a triggered alert instance from /services/alerts/fired_alerts.
+// ResultCount is read from the triggering job and is -1 once the job has expired.
+type TriggeredAlert struct {
+	SavedSearch string `json:"savedsearch_name"`
+	SID         string `json:"sid"`
+	Severity    int    `json:"severity"`
+	TriggerTime string `json:"trigger_time"`
+	Expiration  string `json:"expiration_time"`
+	AlertType   string `json:"alert_type"`
+	DigestMode  bool   `json:"digest_mode"`
+	Actions     string `json:"actions"`
+	ResultCount int    `json:"result_count"`
+	App         string `json:"app"`
+	Owner       string `json:"owner"`
+}
+
+// AlertActionStatus is the outcome of one alert action (email, webhook, ...) run for a triggered alert, read from sendmodalert logs.
+// Status is "success" for exit code 0, "failed" otherwise and "unknown" when no completion was logged.
+// Ambiguous is set when the outcome could only be matched by action name and time, and another run of the same action overlapped.
+type AlertActionStatus struct {
+	Action      string   `json:"action"`
+	InvokedAt   string   `json:"invoked_at"`
+	CompletedAt string   `json:"completed_at,omitempty"`
+	ExitCode    *int     `json:"exit_code,omitempty"`
+	DurationMs  int      `json:"duration_ms,omitempty"`
+	Status      string   `json:"status"`
+	Errors      []string `json:"errors,omitempty"`
+	Ambiguous   bool     `json:"ambiguous,omitempty"`
+}
+
+// GetTriggeredAlerts retrieves paginated triggered alert instances, newest first, optionally for one saved search
+func (c *Client) GetTriggeredAlerts(ctx context.Context, count, offset int, ssName string) ([]TriggeredAlert, int, error) {
+	params := url.Values{}
+	params.Set("count", strconv.Itoa(count))
+	params.Set("offset", strconv.Itoa(offset))
+	params.Set("sort_key", "trigger_time")
+	params.Set("sort_dir", "desc")
+	if ssName != "" && ssName != "*" {
+		params.Set("search", fmt.Sprintf("savedsearch_name=\"%s\"", escapeFilterValue(ssName)))
+	}
+
+	entries, total, err := c.getRESTEntries(ctx, "/servicesNS/-/-/alerts/fired_alerts/-", params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	alerts := make([]TriggeredAlert, len(entries))
+	for i, entry := range entries {
+		content := entry.Content
+		alerts[i] = TriggeredAlert{
+			SavedSearch: getString(content, "savedsearch_name"),
+			SID:         getString(content, "sid"),
+			Severity:    getInt(content, "severity"),
+			TriggerTime: getString(content, "trigger_time_rendered"),
+			Expiration:  getString(content, "expiration_time_rendered"),
+			AlertType:   getString(content, "alert_type"),
+			DigestMode:  getBool(content, "digest_mode"),
+			Actions:     getString(content, "actions"),
+			ResultCount: -1,
+			App:         entry.ACL.App,
+			Owner:       entry.ACL.Owner,
+		}
+		if job, err := c.GetJob(ctx, alerts[i].SID); err == nil {
+			alerts[i].ResultCount = job.ResultCount
+		}
+	}
+
+	return alerts, total, nil
+}
+
+var (
+	sendmodalertAction   = regexp.MustCompile(`action=([^\s"]+)`)
+	sendmodalertWorker   = regexp.MustCompile(`sendmodalert \[([^\]]+)\]`)
+	sendmodalertSID      = regexp.MustCompile(`sid="([^"]+)"`)
+	sendmodalertExitCode = regexp.MustCompile(`exit code=(-?\d+)`)
+	sendmodalertDuration = regexp.MustCompile(`duration=(\d+)`)
+)
+
+// actionCompletionWindow is how long after invocation a completion line is attributed to an action
+const actionCompletionWindow = 10 * time.Minute
+
+// GetAlertActionStatus reads index=_internal sourcetype=splunkd component=sendmodalert for the actions run for a triggering SID.
+// Only the invocation line carries the SID; completion and error lines are matched to it by the "[pid thread]" tag of the
+// worker that ran the action, or by action name and time when the tag is missing.
+func (c *Client) GetAlertActionStatus(ctx context.Context, sid, earliest string) ([]AlertActionStatus, error) {
+	invocations, err := c.exportSearch(ctx, fmt.Sprintf(
+		"search index=_internal sourcetype=splunkd component=sendmodalert earliest=%s %s | table _time _raw | sort 0 _time", earliest, QuoteSPL(sid)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get action invocations: %w", err)
+	}
+
+	var statuses []*AlertActionStatus
+	var first, last time.Time
+	invoked := map[string]time.Time{}
+	workers := map[string]string{}
+	for _, row := range invocations {
+		raw := getString(row, "_raw")
+		m := sendmodalertSID.FindStringSubmatch(raw)
+		if m == nil || m[1] != sid {
+			continue
+		}
+		action := sendmodalertAction.FindStringSubmatch(raw)
+		t, err := time.Parse(time.RFC3339Nano, getString(row, "_time"))
+		if action == nil || err != nil {
+			continue
+		}
+		if _, ok := invoked[action[1]]; ok {
+			continue
+		}
+		invoked[action[1]] = t
+		if w := sendmodalertWorker.FindStringSubmatch(raw); w != nil {
+			workers[action[1]] = w[1]
+		}
+		statuses = append(statuses, &AlertActionStatus{Action: action[1], InvokedAt: getString(row, "_time"), Status: "unknown"})
+		if first.IsZero() || t.Before(first) {
+			first = t
+		}
+		if t.After(last) {
+			last = t
+		}
+	}
+	if len(statuses) == 0 {
+		return []AlertActionStatus{}, nil
+	}
+
+	events, err := c.exportSearch(ctx, fmt.Sprintf(
+		"search index=_internal sourcetype=splunkd component=sendmodalert earliest=%d latest=%d | table _time log_level _raw | sort 0 _time",
+		first.Unix(), last.Add(actionCompletionWindow).Unix()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get action outcomes: %w", err)
+	}
+
+	for _, status := range statuses {
+		start := invoked[status.Action]
+		worker, tagged := workers[status.Action]
+		for _, row := range events {
+			raw := getString(row, "_raw")
+			t, err := time.Parse(time.RFC3339Nano, getString(row, "_time"))
+			if err != nil || t.Before(start) || t.After(start.Add(actionCompletionWindow)) {
+				continue
+			}
+			action := sendmodalertAction.FindStringSubmatch(raw)
+			if action == nil || action[1] != status.Action {
+				continue
+			}
+			if m := sendmodalertSID.FindStringSubmatch(raw); m != nil {
+				// another run of the same action started in the window; without a worker tag its outcome is indistinguishable
+				if m[1] != sid && !tagged && status.ExitCode == nil {
+					status.Ambiguous = true
+				}
+				continue
+			}
+			if tagged {
+				if w := sendmodalertWorker.FindStringSubmatch(raw); w == nil || w[1] != worker {
+					continue
+				}
+			}
+			if code := sendmodalertExitCode.FindStringSubmatch(raw); code != nil {
+				exitCode, _ := strconv.Atoi(code[1])
+				status.ExitCode = &exitCode
+				status.CompletedAt = getString(row, "_time")
+				if d := sendmodalertDuration.FindStringSubmatch(raw); d != nil {
+					status.DurationMs, _ = strconv.Atoi(d[1])
+				}
+				status.Status = "failed"
+				if exitCode == 0 {
+					status.Status = "success"
+				}
+				break
+			}
+			if level := getString(row, "log_level"); (level == "ERROR" || level == "WARN" || strings.Contains(raw, "STDERR")) && len(status.Errors) < 5 {
+				status.Errors = append(status.Errors, raw)
+			}
+		}
+	}
+
+	result := make([]AlertActionStatus, len(statuses))
+	for i, status := range statuses {
+		result[i] = *status
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].InvokedAt < result[j].InvokedAt })
+	return result, nil
+}
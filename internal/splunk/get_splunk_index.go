@@ -0,0 +1,79 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// IndexDetail is an index with its size, event count, time span, retention and storage settings.
+type IndexDetail struct {
+	Index
+	CurrentDBSizeMB        float64 `json:"current_db_size_mb"`
+	MaxTotalDataSizeMB     float64 `json:"max_total_data_size_mb"`
+	TotalEventCount        int     `json:"total_event_count"`
+	MinTime                string  `json:"min_time"`
+	MaxTime                string  `json:"max_time"`
+	FrozenTimePeriodInSecs int     `json:"frozen_time_period_in_secs"`
+	RetentionDays          float64 `json:"retention_days"`
+	HomePath               string  `json:"home_path"`
+	ColdPath               string  `json:"cold_path"`
+	ThawedPath             string  `json:"thawed_path"`
+	RepFactor              string  `json:"rep_factor"`
+}
+
+// GetIndex retrieves a single index of any datatype (event or metric) with its size, event count, time span and retention
+func (c *Client) GetIndex(ctx context.Context, name string) (*IndexDetail, error) {
+	entries, _, err := c.getRESTEntries(ctx, "/services/data/indexes/"+url.PathEscape(name), url.Values{"datatype": {"all"}})
+	if err == errNotFound || (err == nil && len(entries) == 0) {
+		return nil, fmt.Errorf("index %q not found", name)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	detail := indexDetailFromEntry(entries[0])
+	return &detail, nil
+}
+
+// GetIndexDetails retrieves paginated indexes with all their details, filtered and sorted server side
+func (c *Client) GetIndexDetails(ctx context.Context, count, offset int, filter ListFilter) ([]IndexDetail, int, error) {
+	params := url.Values{}
+	params.Set("count", strconv.Itoa(count))
+	params.Set("offset", strconv.Itoa(offset))
+	filter.apply(params, "")
+	entries, total, err := c.getRESTEntries(ctx, "/services/data/indexes", params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	indexes := make([]IndexDetail, len(entries))
+	for i, entry := range entries {
+		indexes[i] = indexDetailFromEntry(entry)
+	}
+	return indexes, total, nil
+}
+
+func indexDetailFromEntry(entry restEntry) IndexDetail {
+	content := entry.Content
+	frozen := getInt(content, "frozenTimePeriodInSecs")
+	return IndexDetail{
+		Index: Index{
+			Name:     entry.Name,
+			Disabled: getBool(content, "disabled"),
+			DataType: getString(content, "datatype"),
+		},
+		CurrentDBSizeMB:        getFloat(content, "currentDBSizeMB"),
+		MaxTotalDataSizeMB:     getFloat(content, "maxTotalDataSizeMB"),
+		TotalEventCount:        getInt(content, "totalEventCount"),
+		MinTime:                getString(content, "minTime"),
+		MaxTime:                getString(content, "maxTime"),
+		FrozenTimePeriodInSecs: frozen,
+		RetentionDays:          float64(frozen) / 86400,
+		HomePath:               getString(content, "homePath_expanded"),
+		ColdPath:               getString(content, "coldPath_expanded"),
+		ThawedPath:             getString(content, "thawedPath_expanded"),
+		RepFactor:              getString(content, "repFactor"),
+	}
+}
@@ -0,0 +1,132 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SavedSearchDetail is a saved search with its schedule, dispatch window, alert and suppression settings
+// and the parameters of its alert actions (e.g. email recipients, webhook URLs).
+type SavedSearchDetail struct {
+	SavedSearch
+	IsScheduled          bool                         `json:"is_scheduled"`
+	CronSchedule         string                       `json:"cron_schedule"`
+	NextScheduledTime    string                       `json:"next_scheduled_time"`
+	DispatchEarliestTime string                       `json:"dispatch_earliest_time"`
+	DispatchLatestTime   string                       `json:"dispatch_latest_time"`
+	AlertType            string                       `json:"alert_type"`
+	AlertComparator      string                       `json:"alert_comparator"`
+	AlertThreshold       string                       `json:"alert_threshold"`
+	AlertCondition       string                       `json:"alert_condition"`
+	AlertSeverity        int                          `json:"alert_severity"`
+	AlertTrack           bool                         `json:"alert_track"`
+	AlertDigestMode      bool                         `json:"alert_digest_mode"`
+	AlertSuppress        bool                         `json:"alert_suppress"`
+	AlertSuppressFields  string                       `json:"alert_suppress_fields"`
+	AlertSuppressPeriod  string                       `json:"alert_suppress_period"`
+	ActionParams         map[string]map[string]string `json:"action_params,omitempty"`
+}
+
+// GetSavedSearch retrieves a single saved search with all its settings.
+// app is optional and resolves the name in that app's namespace when the same name exists in several apps.
+func (c *Client) GetSavedSearch(ctx context.Context, name, app string) (*SavedSearchDetail, error) {
+	entry, err := c.getSavedSearchEntry(ctx, name, app)
+	if err != nil {
+		return nil, err
+	}
+
+	detail := savedSearchDetailFromEntry(*entry)
+	return &detail, nil
+}
+
+// getSavedSearchEntry retrieves the raw REST entry of a saved search, optionally in an app's namespace
+func (c *Client) getSavedSearchEntry(ctx context.Context, name, app string) (*restEntry, error) {
+	namespace := "/services"
+	if app != "" {
+		namespace = fmt.Sprintf("/servicesNS/-/%s", url.PathEscape(app))
+	}
+	entries, _, err := c.getRESTEntries(ctx, fmt.Sprintf("%s/saved/searches/%s", namespace, url.PathEscape(name)), url.Values{})
+	if err == errNotFound || (err == nil && len(entries) == 0) {
+		return nil, fmt.Errorf("saved search %q %w", name, errNotFound)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &entries[0], nil
+}
+
+// GetSavedSearchDetails retrieves paginated saved searches with all their settings, filtered and sorted server side
+func (c *Client) GetSavedSearchDetails(ctx context.Context, count, offset int, filter ListFilter) ([]SavedSearchDetail, int, error) {
+	params := url.Values{}
+	params.Set("count", strconv.Itoa(count))
+	params.Set("offset", strconv.Itoa(offset))
+	filter.apply(params, "search")
+	entries, total, err := c.getRESTEntries(ctx, "/services/saved/searches", params)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	searches := make([]SavedSearchDetail, len(entries))
+	for i, entry := range entries {
+		searches[i] = savedSearchDetailFromEntry(entry)
+	}
+	return searches, total, nil
+}
+
+func savedSearchDetailFromEntry(entry restEntry) SavedSearchDetail {
+	content := entry.Content
+	detail := SavedSearchDetail{
+		SavedSearch: SavedSearch{
+			Name:        entry.Name,
+			Search:      getString(content, "search"),
+			Description: getString(content, "description"),
+			Actions:     getString(content, "actions"),
+			Disabled:    getBool(content, "disabled"),
+			ACL:         entry.ACL.toACL(),
+		},
+		IsScheduled:          getBool(content, "is_scheduled"),
+		CronSchedule:         getString(content, "cron_schedule"),
+		NextScheduledTime:    getString(content, "next_scheduled_time"),
+		DispatchEarliestTime: getString(content, "dispatch.earliest_time"),
+		DispatchLatestTime:   getString(content, "dispatch.latest_time"),
+		AlertType:            getString(content, "alert_type"),
+		AlertComparator:      getString(content, "alert_comparator"),
+		AlertThreshold:       getString(content, "alert_threshold"),
+		AlertCondition:       getString(content, "alert_condition"),
+		AlertTrack:           getBool(content, "alert.track"),
+		AlertDigestMode:      getBool(content, "alert.digest_mode"),
+		AlertSuppress:        getBool(content, "alert.suppress"),
+		AlertSuppressFields:  getString(content, "alert.suppress.fields"),
+		AlertSuppressPeriod:  getString(content, "alert.suppress.period"),
+	}
+	if severity, ok := content["alert.severity"].(float64); ok {
+		detail.AlertSeverity = int(severity)
+	}
+
+	// Collect action.<name>.<param> settings of the enabled actions only, the endpoint returns defaults for every installed action
+	for _, action := range strings.Split(detail.Actions, ",") {
+		action = strings.TrimSpace(action)
+		if action == "" {
+			continue
+		}
+		prefix := "action." + action + "."
+		params := map[string]string{}
+		for key, value := range content {
+			if !strings.HasPrefix(key, prefix) {
+				continue
+			}
+			if s := fmt.Sprintf("%v", value); s != "" {
+				params[strings.TrimPrefix(key, prefix)] = s
+			}
+		}
+		if detail.ActionParams == nil {
+			detail.ActionParams = map[string]map[string]string{}
+		}
+		detail.ActionParams[action] = params
+	}
+
+	return detail
+}
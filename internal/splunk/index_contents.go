@@ -0,0 +1,59 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+)
+
+// IndexValue is one sourcetype, host or source of an index with its event count and first/last event time.
+type IndexValue struct {
+	Value     string `json:"value"`
+	Count     int    `json:"count"`
+	FirstSeen string `json:"first_seen"`
+	LastSeen  string `json:"last_seen"`
+}
+
+// IndexContents is what an index holds over a time range: its sourcetypes, hosts and sources, most events first.
+type IndexContents struct {
+	Index       string       `json:"index"`
+	Earliest    string       `json:"earliest"`
+	Latest      string       `json:"latest"`
+	Sourcetypes []IndexValue `json:"sourcetypes"`
+	Hosts       []IndexValue `json:"hosts"`
+	Sources     []IndexValue `json:"sources"`
+}
+
+// GetIndexContents summarizes the sourcetypes, hosts and sources of an index with tstats (indexed fields only, so it stays cheap on large indexes).
+// count limits the values returned per field.
+func (c *Client) GetIndexContents(ctx context.Context, index, earliest, latest string, count int) (*IndexContents, error) {
+	contents := &IndexContents{Index: index, Earliest: earliest, Latest: latest}
+	for _, field := range []struct {
+		name   string
+		values *[]IndexValue
+	}{
+		{"sourcetype", &contents.Sourcetypes},
+		{"host", &contents.Hosts},
+		{"source", &contents.Sources},
+	} {
+		spl := fmt.Sprintf("| tstats count min(_time) as first_seen max(_time) as last_seen where index=%s earliest=%s latest=%s by %s ", QuoteSPL(index), earliest, latest, field.name) +
+			fmt.Sprintf("| eval first_seen=strftime(first_seen, \"%%Y-%%m-%%dT%%H:%%M:%%S%%z\"), last_seen=strftime(last_seen, \"%%Y-%%m-%%dT%%H:%%M:%%S%%z\") "+
+				"| sort 0 - count | head %d", count)
+
+		rows, err := c.exportSearch(ctx, spl)
+		if err != nil {
+			return nil, fmt.Errorf("failed to summarize %ss: %w", field.name, err)
+		}
+
+		values := make([]IndexValue, len(rows))
+		for i, row := range rows {
+			values[i] = IndexValue{
+				Value:     getString(row, field.name),
+				Count:     getInt(row, "count"),
+				FirstSeen: getString(row, "first_seen"),
+				LastSeen:  getString(row, "last_seen"),
+			}
+		}
+		*field.values = values
+	}
+	return contents, nil
+}
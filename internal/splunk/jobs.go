@@ -0,0 +1,209 @@
+package splunk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Job is the status of a search job, e.g. the job that triggered an alert.
+type Job struct {
+	SID           string  `json:"sid"`
+	Search        string  `json:"search"`
+	DispatchState string  `json:"dispatch_state"`
+	IsDone        bool    `json:"is_done"`
+	IsFailed      bool    `json:"is_failed"`
+	ResultCount   int     `json:"result_count"`
+	EventCount    int     `json:"event_count"`
+	RunDuration   float64 `json:"run_duration_seconds"`
+	EarliestTime  string  `json:"earliest_time"`
+	LatestTime    string  `json:"latest_time"`
+	TTL           int     `json:"ttl_seconds"`
+}
+
+// GetJob retrieves the status of a search job by SID
+func (c *Client) GetJob(ctx context.Context, sid string) (*Job, error) {
+	entries, _, err := c.getRESTEntries(ctx, "/services/search/jobs/"+url.PathEscape(sid), url.Values{})
+	if err == errNotFound || (err == nil && len(entries) == 0) {
+		return nil, fmt.Errorf("job %q not found (it may have expired)", sid)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	content := entries[0].Content
+	return &Job{
+		SID:           sid,
+		Search:        getString(content, "eventSearch"),
+		DispatchState: getString(content, "dispatchState"),
+		IsDone:        getBool(content, "isDone"),
+		IsFailed:      getBool(content, "isFailed"),
+		ResultCount:   getInt(content, "resultCount"),
+		EventCount:    getInt(content, "eventCount"),
+		RunDuration:   getFloat(content, "runDuration"),
+		EarliestTime:  getString(content, "earliestTime"),
+		LatestTime:    getString(content, "latestTime"),
+		TTL:           getInt(content, "ttl"),
+	}, nil
+}
+
+// GetJobResults retrieves paginated results of a finished search job; total is the job's result count
+func (c *Client) GetJobResults(ctx context.Context, sid string, count, offset int) ([]map[string]interface{}, int, error) {
+	job, err := c.GetJob(ctx, sid)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	endpoint := fmt.Sprintf("%s/services/search/jobs/%s/results?output_mode=json&count=%d&offset=%d", c.BaseURL, url.PathEscape(sid), count, offset)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AuthToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Splunk answers 204 No Content while the job is still running
+	if resp.StatusCode == http.StatusNoContent {
+		return nil, 0, fmt.Errorf("job %q is not done yet (dispatch state %s), retry once it has finished", sid, job.DispatchState)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Results []map[string]interface{} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return result.Results, job.ResultCount, nil
+}
+
+// jobPollInterval is how often WaitForJob checks the job status; jobDeleteTimeout bounds the cleanup of a finished job
+const (
+	jobPollInterval  = time.Second
+	jobDeleteTimeout = 10 * time.Second
+)
+
+// CreateJob starts an async search job over [earliest, latest) and returns its SID
+func (c *Client) CreateJob(ctx context.Context, spl, earliest, latest string) (string, error) {
+	return c.createJob(ctx, jobForm(spl, earliest, latest))
+}
+
+// createJob starts an async search job from the given job parameters (search, time range, sample_ratio, ...)
+func (c *Client) createJob(ctx context.Context, form url.Values) (string, error) {
+	var result struct {
+		SID string `json:"sid"`
+	}
+	if err := c.doForm(ctx, "POST", "/services/search/jobs", form, &result); err != nil {
+		return "", err
+	}
+	if result.SID == "" {
+		return "", fmt.Errorf("search job returned no sid")
+	}
+	return result.SID, nil
+}
+
+// jobForm builds the parameters of a normal (async) search job over [earliest, latest)
+func jobForm(spl, earliest, latest string) url.Values {
+	form := url.Values{}
+	form.Set("search", searchCommand(spl))
+	form.Set("exec_mode", "normal")
+	if earliest != "" {
+		form.Set("earliest_time", earliest)
+	}
+	if latest != "" {
+		form.Set("latest_time", latest)
+	}
+	return form
+}
+
+// WaitForJob polls a job until it is done, failed or ctx is cancelled
+func (c *Client) WaitForJob(ctx context.Context, sid string) (*Job, error) {
+	ticker := time.NewTicker(jobPollInterval)
+	defer ticker.Stop()
+	for {
+		job, err := c.GetJob(ctx, sid)
+		if err != nil {
+			return nil, err
+		}
+		if job.IsFailed || job.DispatchState == "FAILED" {
+			return nil, fmt.Errorf("search job %s failed", sid)
+		}
+		if job.IsDone {
+			return job, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// RunJob runs a search job to completion and returns its status and up to count results.
+// It waits for one of the client's job slots, so concurrent callers never exceed DefaultMaxConcurrentJobs.
+func (c *Client) RunJob(ctx context.Context, spl, earliest, latest string, count int) (*Job, []map[string]interface{}, error) {
+	return c.runJob(ctx, jobForm(spl, earliest, latest), count)
+}
+
+func (c *Client) runJob(ctx context.Context, form url.Values, count int) (*Job, []map[string]interface{}, error) {
+	select {
+	case c.jobSlots <- struct{}{}:
+		defer func() { <-c.jobSlots }()
+	case <-ctx.Done():
+		return nil, nil, ctx.Err()
+	}
+
+	sid, err := c.createJob(ctx, form)
+	if err != nil {
+		return nil, nil, err
+	}
+	// the results are returned to the caller, so the job is removed instead of counting against the disk quota until its ttl
+	defer c.deleteJob(ctx, sid)
+
+	job, err := c.WaitForJob(ctx, sid)
+	if err != nil {
+		return nil, nil, err
+	}
+	if count == 0 || job.ResultCount == 0 {
+		return job, nil, nil
+	}
+	results, _, err := c.GetJobResults(ctx, sid, count, 0)
+	if err != nil {
+		return nil, nil, err
+	}
+	return job, results, nil
+}
+
+// deleteJob cancels a search job and removes its artifacts; it also runs after ctx is cancelled, so abandoned jobs don't linger
+func (c *Client) deleteJob(ctx context.Context, sid string) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jobDeleteTimeout)
+	defer cancel()
+	if err := c.doForm(ctx, "DELETE", "/services/search/jobs/"+url.PathEscape(sid), nil, nil); err != nil {
+		log.Printf("failed to delete search job %s: %v", sid, err)
+	}
+}
+
+// searchCommand prefixes SPL with the search command unless it starts with a generating command (| ...)
+func searchCommand(spl string) string {
+	spl = strings.TrimSpace(spl)
+	if strings.HasPrefix(spl, "|") || strings.HasPrefix(spl, "search ") {
+		return spl
+	}
+	return "search " + spl
+}
@@ -0,0 +1,169 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+)
+
+// licenseSplitFields maps the split_by argument to the license_usage.log field
+var licenseSplitFields = map[string]string{
+	"index":      "idx",
+	"sourcetype": "st",
+	"host":       "h",
+	"source":     "s",
+	"pool":       "pool",
+}
+
+// LicenseUsage is the ingest of one index, sourcetype, host, source or pool over the window, compared with the window before it.
+// ChangePct is nil when nothing was ingested in the previous window.
+type LicenseUsage struct {
+	Value       string   `json:"value"`
+	GB          float64  `json:"gb"`
+	AvgDailyGB  float64  `json:"avg_daily_gb"`
+	PeakDailyGB float64  `json:"peak_daily_gb"`
+	PreviousGB  float64  `json:"previous_gb"`
+	ChangePct   *float64 `json:"change_pct"`
+}
+
+// LicenseDay is the total ingest of one day.
+type LicenseDay struct {
+	Date string  `json:"date"`
+	GB   float64 `json:"gb"`
+}
+
+// LicensePool is a license pool with its quota and today's usage.
+type LicensePool struct {
+	Name      string  `json:"name"`
+	StackID   string  `json:"stack_id"`
+	QuotaGB   float64 `json:"quota_gb"`
+	UsedGB    float64 `json:"used_gb"`
+	UsedPct   float64 `json:"used_pct"`
+	Unlimited bool    `json:"unlimited,omitempty"`
+}
+
+// LicenseMessage is a licenser warning or violation message.
+type LicenseMessage struct {
+	Category    string `json:"category"`
+	Severity    string `json:"severity"`
+	Pool        string `json:"pool,omitempty"`
+	Description string `json:"description"`
+	Created     string `json:"create_time"`
+}
+
+// LicenseUsageReport is the daily ingest over a window, the top consumers with their trend, and the licenser's pools and messages.
+// LicenserErrors lists why pools or messages could not be read (e.g. a 403 for roles without license access); the usage is still reported.
+type LicenseUsageReport struct {
+	Days           int              `json:"days"`
+	SplitBy        string           `json:"split_by"`
+	TotalGB        float64          `json:"total_gb"`
+	PreviousGB     float64          `json:"previous_gb"`
+	Daily          []LicenseDay     `json:"daily"`
+	Top            []LicenseUsage   `json:"top"`
+	Pools          []LicensePool    `json:"pools"`
+	Messages       []LicenseMessage `json:"messages"`
+	LicenserErrors []string         `json:"licenser_errors,omitempty"`
+}
+
+// GetLicenseUsage reports ingest from index=_internal source=*license_usage.log type=Usage over the last days (whole days),
+// the top count values of splitBy with their trend versus the days before, and pool quotas and violations from /services/licenser.
+// The licenser endpoints are best effort: when they fail, the report keeps the usage and records the error in LicenserErrors.
+// Values squashed by the license manager are reported as "(squashed)".
+func (c *Client) GetLicenseUsage(ctx context.Context, splitBy string, days, count int) (*LicenseUsageReport, error) {
+	field, ok := licenseSplitFields[splitBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported split_by %q, use index, sourcetype, host, source or pool", splitBy)
+	}
+	base := fmt.Sprintf("search index=_internal source=*license_usage.log type=Usage earliest=-%dd@d latest=@d ", 2*days) +
+		fmt.Sprintf("| eval period=if(_time>=relative_time(now(), \"-%dd@d\"), \"current\", \"previous\") ", days)
+
+	spl := base + fmt.Sprintf("| eval value=coalesce(nullif(%s, \"\"), \"(squashed)\"), day=strftime(_time, \"%%Y-%%m-%%d\") ", field) +
+		"| stats sum(b) as bytes by value period day " +
+		"| stats sum(eval(if(period=\"current\", bytes, 0))) as current max(eval(if(period=\"current\", bytes, null()))) as peak " +
+		"sum(eval(if(period=\"previous\", bytes, 0))) as previous by value " +
+		fmt.Sprintf("| where current > 0 | sort 0 - current | head %d", count)
+	rows, err := c.exportSearch(ctx, spl)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &LicenseUsageReport{Days: days, SplitBy: splitBy, Daily: []LicenseDay{}, Top: []LicenseUsage{}, Pools: []LicensePool{}, Messages: []LicenseMessage{}}
+	for _, row := range rows {
+		usage := LicenseUsage{
+			Value:       getString(row, "value"),
+			GB:          toGB(getFloat(row, "current")),
+			AvgDailyGB:  toGB(getFloat(row, "current") / float64(days)),
+			PeakDailyGB: toGB(getFloat(row, "peak")),
+			PreviousGB:  toGB(getFloat(row, "previous")),
+		}
+		if previous := getFloat(row, "previous"); previous > 0 {
+			change := math.Round((getFloat(row, "current")-previous)/previous*1000) / 10
+			usage.ChangePct = &change
+		}
+		report.Top = append(report.Top, usage)
+	}
+
+	spl = base + "| eval day=if(period=\"current\", strftime(_time, \"%Y-%m-%d\"), \"previous\") | stats sum(b) as bytes by day | sort 0 day"
+	rows, err = c.exportSearch(ctx, spl)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		day, bytes := getString(row, "day"), getFloat(row, "bytes")
+		if day == "previous" {
+			report.PreviousGB = toGB(bytes)
+			continue
+		}
+		report.Daily = append(report.Daily, LicenseDay{Date: day, GB: toGB(bytes)})
+		report.TotalGB += bytes
+	}
+	report.TotalGB = toGB(report.TotalGB)
+
+	pools, err := c.getAllRESTEntries(ctx, "/services/licenser/pools")
+	if err != nil {
+		report.LicenserErrors = append(report.LicenserErrors, "failed to get license pools: "+err.Error())
+	}
+	for _, entry := range pools {
+		pool := LicensePool{
+			Name:    entry.Name,
+			StackID: getString(entry.Content, "stack_id"),
+			UsedGB:  toGB(getFloat(entry.Content, "used_bytes")),
+		}
+		quota := getFloat(entry.Content, "effective_quota")
+		if quota == 0 {
+			quota = getFloat(entry.Content, "quota")
+		}
+		if quota > 0 {
+			pool.QuotaGB = toGB(quota)
+			pool.UsedPct = math.Round(getFloat(entry.Content, "used_bytes")/quota*1000) / 10
+		} else {
+			pool.Unlimited = true
+		}
+		report.Pools = append(report.Pools, pool)
+	}
+
+	messages, err := c.getAllRESTEntries(ctx, "/services/licenser/messages")
+	if err != nil {
+		report.LicenserErrors = append(report.LicenserErrors, "failed to get license messages: "+err.Error())
+	}
+	for _, entry := range messages {
+		created := ""
+		if epoch := getFloat(entry.Content, "create_time"); epoch > 0 {
+			created = time.Unix(int64(epoch), 0).UTC().Format(time.RFC3339)
+		}
+		report.Messages = append(report.Messages, LicenseMessage{
+			Category:    getString(entry.Content, "category"),
+			Severity:    getString(entry.Content, "severity"),
+			Pool:        getString(entry.Content, "pool_id"),
+			Description: getString(entry.Content, "description"),
+			Created:     created,
+		})
+	}
+	return report, nil
+}
+
+// toGB converts bytes to GB rounded to 3 decimals
+func toGB(bytes float64) float64 {
+	return math.Round(bytes/(1<<30)*1000) / 1000
+}
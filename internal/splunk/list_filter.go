@@ -0,0 +1,86 @@
+package splunk
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// ListFilter narrows a REST list endpoint server side using Splunk's `search`, `sort_key` and `sort_dir` parameters,
+// so one call finds the matching objects instead of paging through everything. Zero values are not filtered on.
+type ListFilter struct {
+	Name      string // case-insensitive substring of the object name
+	Text      string // case-insensitive substring of the SPL (search or macro definition)
+	App       string
+	Owner     string
+	Disabled  *bool
+	Scheduled *bool  // saved searches only
+	DataType  string // indexes only: event (Splunk's default), metric or all
+	SortKey   string // any content field, e.g. name or cron_schedule
+	SortDir   string // asc or desc
+}
+
+// searchExpr builds the REST `search` expression; textField is the content field holding the SPL ("" when the object has none)
+func (f ListFilter) searchExpr(textField string) string {
+	var terms []string
+	if f.Name != "" {
+		terms = append(terms, fmt.Sprintf("name=\"*%s*\"", escapeFilterValue(f.Name)))
+	}
+	if f.Text != "" && textField != "" {
+		terms = append(terms, fmt.Sprintf("%s=\"*%s*\"", textField, escapeFilterValue(f.Text)))
+	}
+	if f.App != "" {
+		terms = append(terms, fmt.Sprintf("eai:acl.app=\"%s\"", escapeFilterValue(f.App)))
+	}
+	if f.Owner != "" {
+		terms = append(terms, fmt.Sprintf("eai:acl.owner=\"%s\"", escapeFilterValue(f.Owner)))
+	}
+	if f.Disabled != nil {
+		terms = append(terms, fmt.Sprintf("disabled=%d", boolToInt(*f.Disabled)))
+	}
+	if f.Scheduled != nil {
+		terms = append(terms, fmt.Sprintf("is_scheduled=%d", boolToInt(*f.Scheduled)))
+	}
+	return strings.Join(terms, " ")
+}
+
+// filterValueEscaper escapes a value for a quoted term of the REST `search` filter, so quotes can't end the term
+// and backslashes and asterisks match literally instead of escaping or wildcarding
+var filterValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, `*`, `\*`)
+
+func escapeFilterValue(v string) string {
+	return filterValueEscaper.Replace(v)
+}
+
+// apply adds the filter and sort parameters to a REST query
+func (f ListFilter) apply(params url.Values, textField string) {
+	if expr := f.searchExpr(textField); expr != "" {
+		params.Set("search", expr)
+	}
+	if f.DataType != "" {
+		params.Set("datatype", f.DataType)
+	}
+	if f.SortKey != "" {
+		params.Set("sort_key", f.SortKey)
+	}
+	if f.SortDir != "" {
+		params.Set("sort_dir", f.SortDir)
+	}
+}
+
+// query encodes the filter as a query string suffix for URLs built with fmt.Sprintf
+func (f ListFilter) query(textField string) string {
+	params := url.Values{}
+	f.apply(params, textField)
+	if len(params) == 0 {
+		return ""
+	}
+	return "&" + params.Encode()
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
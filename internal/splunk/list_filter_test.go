@@ -0,0 +1,28 @@
+package splunk
+
+import "testing"
+
+func TestListFilterSearchExprEscapesValues(t *testing.T) {
+	disabled := false
+	tests := []struct {
+		name   string
+		filter ListFilter
+		want   string
+	}{
+		{"empty", ListFilter{}, ``},
+		{"plain name", ListFilter{Name: "okta"}, `name="*okta*"`},
+		{"quoted search text", ListFilter{Text: `index="main"`}, `search="*index=\"main\"*"`},
+		{"quote cannot inject terms", ListFilter{Name: `x" OR name="*`}, `name="*x\" OR name=\"\**"`},
+		{"literal asterisk", ListFilter{Text: "count(*)"}, `search="*count(\*)*"`},
+		{"backslash", ListFilter{Text: `C:\logs`}, `search="*C:\\logs*"`},
+		{"app and owner", ListFilter{App: `my"app`, Owner: "admin*"}, `eai:acl.app="my\"app" eai:acl.owner="admin\*"`},
+		{"all terms", ListFilter{Name: "a", Text: "b", App: "c", Owner: "d", Disabled: &disabled}, `name="*a*" search="*b*" eai:acl.app="c" eai:acl.owner="d" disabled=0`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.filter.searchExpr("search"); got != tt.want {
+				t.Errorf("searchExpr() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,97 +2,162 @@ package splunk
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
-	"io"
-	"net/http"
+	"math"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // Alert represents an alert definition from Splunk
 // Only includes alerts with actions, and supports title filtering
-// Fields: title, search, alert_type, actions, disabled, description
+// Fields: title, search, alert_type, actions, disabled, description, suppression settings and the ACL (app, owner, sharing, roles)
 type Alert struct {
-	Title       string `json:"title"`
-	Search      string `json:"search"`
-	AlertType   string `json:"alert_type"`
-	Actions     string `json:"actions"`
-	Disabled    bool   `json:"disabled"`
-	Description string `json:"description"`
+	Title          string `json:"title"`
+	Search         string `json:"search"`
+	AlertType      string `json:"alert_type"`
+	Actions        string `json:"actions"`
+	Disabled       bool   `json:"disabled"`
+	Description    string `json:"description"`
+	Suppress       bool   `json:"suppress"`
+	SuppressFields string `json:"suppress_fields,omitempty"`
+	SuppressPeriod string `json:"suppress_period,omitempty"`
+	ACL
 }
 
-// GetAlerts retrieves paginated alerts from Splunk using SPL, with optional case-insensitive title filter
-func (c *Client) GetAlerts(ctx context.Context, count, offset int, title string) ([]Alert, int, error) {
-	// Build SPL
-	spl := "| rest /services/saved/searches | search actions!=\"\" "
-	if title != "" {
-		title = strings.ToLower(title)
-		spl += fmt.Sprintf("| where like(lower(title), \"%%%s%%\") ", title)
-	}
-	spl += "| table title search alert_type actions disabled description"
-
-	// Prepare request to /services/search/jobs/export
-	endpoint := fmt.Sprintf("%s/services/search/jobs/export", c.BaseURL)
-	form := url.Values{}
-	form.Set("search", spl)
-	form.Set("output_mode", "json")
+// GetAlerts retrieves paginated alerts (saved searches with actions) from Splunk.
+// Filtering and paging are done by the REST endpoint, so only the requested page is transferred and total is Splunk's count of matching alerts.
+// filter.Name is the case-insensitive title filter.
+func (c *Client) GetAlerts(ctx context.Context, count, offset int, filter ListFilter) ([]Alert, int, error) {
+	params := url.Values{}
+	params.Set("count", strconv.Itoa(count))
+	params.Set("offset", strconv.Itoa(offset))
+	filter.apply(params, "search")
+	params.Set("search", strings.TrimSpace("actions!=\"\" "+params.Get("search")))
 
-	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	entries, total, err := c.getRESTEntries(ctx, "/services/saved/searches", params)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, err
+	}
+
+	alerts := make([]Alert, len(entries))
+	for i, entry := range entries {
+		alerts[i] = Alert{
+			Title:          entry.Name,
+			Search:         getString(entry.Content, "search"),
+			AlertType:      getString(entry.Content, "alert_type"),
+			Actions:        getString(entry.Content, "actions"),
+			Disabled:       getBool(entry.Content, "disabled"),
+			Description:    getString(entry.Content, "description"),
+			Suppress:       getBool(entry.Content, "alert.suppress"),
+			SuppressFields: getString(entry.Content, "alert.suppress.fields"),
+			SuppressPeriod: getString(entry.Content, "alert.suppress.period"),
+			ACL:            entry.ACL.toACL(),
+		}
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AuthToken))
-	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return alerts, total, nil
+}
+
+// AlertSuppression is the suppression configuration of an alert and whether it is currently suppressed.
+// ExpiresIn is the remaining suppression time after the alert's last fire.
+type AlertSuppression struct {
+	Title          string `json:"title"`
+	App            string `json:"app"`
+	SuppressFields string `json:"suppress_fields,omitempty"`
+	SuppressPeriod string `json:"suppress_period"`
+	Suppressed     bool   `json:"suppressed"`
+	LastFired      string `json:"last_fired,omitempty"`
+	ExpiresIn      string `json:"expires_in,omitempty"`
+}
+
+// GetActiveSuppressions lists the alerts with alert.suppress enabled from one saved search listing, and derives their state
+// from one scheduler log search: an alert is suppressed while its last fire is less than its suppression period ago.
+// With suppress fields, only results with the field values of that fire are held back.
+// Unless includeInactive is set, only alerts that are suppressed right now are returned.
+func (c *Client) GetActiveSuppressions(ctx context.Context, filter ListFilter, includeInactive bool) ([]AlertSuppression, error) {
+	params := url.Values{}
+	params.Set("count", "0")
+	filter.apply(params, "search")
 
-	resp, err := c.HTTP.Do(req)
+	entries, _, err := c.getRESTEntries(ctx, "/services/saved/searches", params)
 	if err != nil {
-		return nil, 0, fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
+	}
+
+	var suppressions []AlertSuppression
+	periods := map[string]time.Duration{}
+	var longest time.Duration
+	for _, entry := range entries {
+		if getString(entry.Content, "actions") == "" || !getBool(entry.Content, "alert.suppress") {
+			continue
+		}
+		suppression := AlertSuppression{
+			Title:          entry.Name,
+			App:            entry.ACL.App,
+			SuppressFields: getString(entry.Content, "alert.suppress.fields"),
+			SuppressPeriod: getString(entry.Content, "alert.suppress.period"),
+		}
+		if period, err := ParseInterval(suppression.SuppressPeriod); err == nil && period > 0 {
+			periods[suppression.App+"/"+suppression.Title] = period
+			if period > longest {
+				longest = period
+			}
+		}
+		suppressions = append(suppressions, suppression)
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode != http.StatusOK {
-		return nil, 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	lastFired := map[string]time.Time{}
+	if longest > 0 {
+		rows, err := c.exportSearch(ctx, fmt.Sprintf(
+			"search index=_internal sourcetype=scheduler fired=1 earliest=-%ds | stats max(_time) as last_fired by app savedsearch_name", int(longest.Seconds())))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get last alert fires: %w", err)
+		}
+		for _, row := range rows {
+			sec, frac := math.Modf(getFloat(row, "last_fired"))
+			lastFired[getString(row, "app")+"/"+getString(row, "savedsearch_name")] = time.Unix(int64(sec), int64(frac*1e9))
+		}
 	}
 
-	// Parse streaming JSON results
-	dec := json.NewDecoder(resp.Body)
-	var alerts []Alert
-	for {
-		var row map[string]interface{}
-		if err := dec.Decode(&row); err != nil {
-			if err == io.EOF {
-				break
+	now := time.Now()
+	result := []AlertSuppression{}
+	for _, suppression := range suppressions {
+		key := suppression.App + "/" + suppression.Title
+		if fired, ok := lastFired[key]; ok {
+			suppression.LastFired = fired.Format(time.RFC3339)
+			if remaining := fired.Add(periods[key]).Sub(now); remaining > 0 {
+				suppression.Suppressed = true
+				suppression.ExpiresIn = remaining.Round(time.Second).String()
 			}
-			return nil, 0, fmt.Errorf("failed to decode response: %w", err)
 		}
-		// Each row is a result with fields under the 'result' key
-		if result, ok := row["result"].(map[string]interface{}); ok {
-			alert := Alert{
-				Title:       getString(result, "title"),
-				Search:      getString(result, "search"),
-				AlertType:   getString(result, "alert_type"),
-				Actions:     getString(result, "actions"),
-				Description: getString(result, "description"),
-			}
-			if disabled, ok := result["disabled"].(string); ok {
-				alert.Disabled = (disabled == "1" || strings.ToLower(disabled) == "true")
-			}
-			alerts = append(alerts, alert)
+		if suppression.Suppressed || includeInactive {
+			result = append(result, suppression)
 		}
 	}
+	return result, nil
+}
 
-	// Mimicking Pagination and total counting, since SPL queries returns everything.
-	total := len(alerts)
-	start := offset
-	if start > total {
-		start = total
+// SetAlertSuppression previews or applies alert.suppress, alert.suppress.fields and alert.suppress.period of an alert.
+// It is an UpdateSavedSearch limited to the suppression settings, so it follows the same dry run and preview token flow.
+func (c *Client) SetAlertSuppression(ctx context.Context, name, app string, suppress bool, fields, period string, dryRun bool, previewToken string) (*WritePreview, error) {
+	settings := map[string]string{"alert.suppress": contentString(suppress)}
+	if fields != "" {
+		settings["alert.suppress.fields"] = fields
+	}
+	if period != "" {
+		settings["alert.suppress.period"] = period
 	}
-	end := start + count
-	if end > total {
-		end = total
+	if suppress && period == "" {
+		entry, err := c.getSavedSearchEntry(ctx, name, app)
+		if err != nil {
+			return nil, err
+		}
+		if getString(entry.Content, "alert.suppress.period") == "" {
+			return nil, fmt.Errorf("period is required to enable suppression, e.g. 1h")
+		}
 	}
-	// But we provide cursor only the "count" of results. The real total is however known to the MCP backend and provided properly.
-	return alerts[start:end], total, nil
+
+	return c.UpdateSavedSearch(ctx, name, app, settings, dryRun, previewToken)
 }
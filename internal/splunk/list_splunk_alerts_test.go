@@ -0,0 +1,113 @@
+package splunk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetAlertsTransfersOnlyRequestedPage(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.URL.Path != "/services/saved/searches" {
+			t.Errorf("unexpected path %s, alerts must not be fetched through search export", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		q := r.URL.Query()
+		if q.Get("count") != "2" || q.Get("offset") != "4" {
+			t.Errorf("expected count=2 offset=4, got count=%s offset=%s", q.Get("count"), q.Get("offset"))
+		}
+		if want := `actions!="" name="*okta*"`; q.Get("search") != want {
+			t.Errorf("expected search %q, got %q", want, q.Get("search"))
+		}
+
+		// A fake Splunk with 7 matching alerts returns only the requested page
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"entry": []map[string]interface{}{
+				{"name": "BT_Alert_OKTA_5", "content": map[string]interface{}{"search": "index=okta", "actions": "email", "disabled": false}, "acl": map[string]interface{}{"app": "search", "owner": "admin"}},
+				{"name": "BT_Alert_OKTA_6", "content": map[string]interface{}{"search": "index=okta", "actions": "webhook", "disabled": true}, "acl": map[string]interface{}{"app": "search", "owner": "nobody"}},
+			},
+			"paging": map[string]interface{}{"total": 7, "perPage": 2, "offset": 4},
+		})
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "token")
+	alerts, total, err := client.GetAlerts(context.Background(), 2, 4, ListFilter{Name: "okta"})
+	if err != nil {
+		t.Fatalf("GetAlerts: %v", err)
+	}
+
+	if requests != 1 {
+		t.Errorf("expected a single request, got %d", requests)
+	}
+	if total != 7 {
+		t.Errorf("expected total 7, got %d", total)
+	}
+	if len(alerts) != 2 {
+		t.Fatalf("expected 2 alerts, got %d", len(alerts))
+	}
+	if alerts[0].Title != "BT_Alert_OKTA_5" || alerts[0].Actions != "email" || alerts[0].App != "search" {
+		t.Errorf("unexpected first alert: %+v", alerts[0])
+	}
+	if !alerts[1].Disabled || alerts[1].Owner != "nobody" {
+		t.Errorf("unexpected second alert: %+v", alerts[1])
+	}
+}
+
+func TestGetActiveSuppressionsUsesOneListingAndOneSearch(t *testing.T) {
+	recent := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+	old := strconv.FormatInt(time.Now().Add(-3*time.Hour).Unix(), 10)
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.Path)
+		switch r.URL.Path {
+		case "/services/saved/searches":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"entry": []map[string]interface{}{
+					{"name": "okta burst", "content": map[string]interface{}{"actions": "email", "alert.suppress": true, "alert.suppress.period": "1h"}, "acl": map[string]interface{}{"app": "sec"}},
+					{"name": "vpn down", "content": map[string]interface{}{"actions": "webhook", "alert.suppress": "1", "alert.suppress.period": "1h"}, "acl": map[string]interface{}{"app": "ops"}},
+					{"name": "not throttled", "content": map[string]interface{}{"actions": "email", "alert.suppress": false}, "acl": map[string]interface{}{"app": "sec"}},
+					{"name": "report", "content": map[string]interface{}{"actions": "", "alert.suppress": true, "alert.suppress.period": "1h"}, "acl": map[string]interface{}{"app": "sec"}},
+				},
+			})
+		case "/services/search/jobs/export":
+			if want := "earliest=-3600s"; !strings.Contains(r.FormValue("search"), want) {
+				t.Errorf("expected the search to cover the longest period (%s), got %q", want, r.FormValue("search"))
+			}
+			enc := json.NewEncoder(w)
+			enc.Encode(map[string]interface{}{"result": map[string]interface{}{"app": "sec", "savedsearch_name": "okta burst", "last_fired": recent}})
+			enc.Encode(map[string]interface{}{"result": map[string]interface{}{"app": "ops", "savedsearch_name": "vpn down", "last_fired": old}})
+		default:
+			t.Errorf("unexpected request %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	client := NewClient(srv.URL, "token")
+	suppressions, err := client.GetActiveSuppressions(context.Background(), ListFilter{}, true)
+	if err != nil {
+		t.Fatalf("GetActiveSuppressions: %v", err)
+	}
+
+	if len(requests) != 2 {
+		t.Errorf("expected one listing and one search, got %v", requests)
+	}
+	if len(suppressions) != 2 {
+		t.Fatalf("expected the 2 alerts with suppression enabled, got %+v", suppressions)
+	}
+	if s := suppressions[0]; s.Title != "okta burst" || !s.Suppressed || s.ExpiresIn == "" {
+		t.Errorf("expected okta burst to be suppressed for about 50m, got %+v", s)
+	}
+	if s := suppressions[1]; s.Title != "vpn down" || s.Suppressed || s.LastFired == "" {
+		t.Errorf("expected vpn down to have fired but no longer be suppressed, got %+v", s)
+	}
+}
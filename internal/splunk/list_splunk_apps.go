@@ -0,0 +1,151 @@
+package splunk
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+// App represents an installed Splunk app or add-on.
+type App struct {
+	Name            string `json:"name"`
+	Label           string `json:"label"`
+	Version         string `json:"version"`
+	Visible         bool   `json:"visible"`
+	Disabled        bool   `json:"disabled"`
+	UpdateAvailable bool   `json:"update_available"`
+	UpdateVersion   string `json:"update_version,omitempty"`
+}
+
+// AppDetail is an App with the counts of its knowledge objects by type (e.g. savedsearches, macros, views).
+type AppDetail struct {
+	App
+	KnowledgeObjects map[string]int `json:"knowledge_objects"`
+}
+
+// GetApps retrieves paginated locally installed apps from Splunk
+func (c *Client) GetApps(ctx context.Context, count, offset int) ([]App, int, error) {
+	url := fmt.Sprintf("%s/services/apps/local?output_mode=json&count=%d&offset=%d", c.BaseURL, count, offset)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AuthToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// Splunk API response
+	var result struct {
+		Entry  []appEntry `json:"entry"`
+		Paging struct {
+			Total   int `json:"total"`
+			PerPage int `json:"perPage"`
+			Offset  int `json:"offset"`
+		} `json:"paging"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	apps := make([]App, len(result.Entry))
+	for i, entry := range result.Entry {
+		apps[i] = entry.toApp()
+	}
+
+	return apps, result.Paging.Total, nil
+}
+
+// GetApp retrieves a single app and counts its knowledge objects by type
+func (c *Client) GetApp(ctx context.Context, name string) (*AppDetail, error) {
+	endpoint := fmt.Sprintf("%s/services/apps/local/%s?output_mode=json", c.BaseURL, url.PathEscape(name))
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AuthToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("app %q not found", name)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Entry []appEntry `json:"entry"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	if len(result.Entry) == 0 {
+		return nil, fmt.Errorf("app %q not found", name)
+	}
+
+	// The directory endpoint lists every knowledge object visible to the caller together with its owning app
+	spl := fmt.Sprintf("| rest /servicesNS/-/-/directory count=0 splunk_server=local | search eai:acl.app=%s | stats count by eai:type", QuoteSPL(name))
+	rows, err := c.exportSearch(ctx, spl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count knowledge objects: %w", err)
+	}
+
+	detail := &AppDetail{
+		App:              result.Entry[0].toApp(),
+		KnowledgeObjects: map[string]int{},
+	}
+	for _, row := range rows {
+		n, err := strconv.Atoi(getString(row, "count"))
+		if err != nil {
+			continue
+		}
+		detail.KnowledgeObjects[getString(row, "eai:type")] = n
+	}
+
+	return detail, nil
+}
+
+// appEntry is a single entry of the /services/apps/local response
+type appEntry struct {
+	Name    string `json:"name"`
+	Content struct {
+		Label         string `json:"label"`
+		Version       string `json:"version"`
+		Visible       bool   `json:"visible"`
+		Disabled      bool   `json:"disabled"`
+		UpdateVersion string `json:"update.version"`
+	} `json:"content"`
+}
+
+func (e appEntry) toApp() App {
+	return App{
+		Name:            e.Name,
+		Label:           e.Content.Label,
+		Version:         e.Content.Version,
+		Visible:         e.Content.Visible,
+		Disabled:        e.Content.Disabled,
+		UpdateAvailable: e.Content.UpdateVersion != "",
+		UpdateVersion:   e.Content.UpdateVersion,
+	}
+}
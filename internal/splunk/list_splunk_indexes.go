@@ -7,15 +7,16 @@ import (
 	"net/http"
 )
 
-// Index represents a Splunk index with name and disabled fields.
+// Index represents a Splunk index with name, disabled and datatype (event or metric) fields.
 type Index struct {
 	Name     string `json:"name"`
 	Disabled bool   `json:"disabled"`
+	DataType string `json:"datatype"`
 }
 
-// GetIndexes retrieves paginated indexes from Splunk
-func (c *Client) GetIndexes(ctx context.Context, count, offset int) ([]Index, int, error) {
-	url := fmt.Sprintf("%s/services/data/indexes?output_mode=json&count=%d&offset=%d", c.BaseURL, count, offset)
+// GetIndexes retrieves paginated indexes from Splunk, filtered and sorted server side
+func (c *Client) GetIndexes(ctx context.Context, count, offset int, filter ListFilter) ([]Index, int, error) {
+	url := fmt.Sprintf("%s/services/data/indexes?output_mode=json&count=%d&offset=%d%s", c.BaseURL, count, offset, filter.query(""))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -40,7 +41,8 @@ func (c *Client) GetIndexes(ctx context.Context, count, offset int) ([]Index, in
 		Entry []struct {
 			Name    string `json:"name"`
 			Content struct {
-				Disabled bool `json:"disabled"`
+				Disabled bool   `json:"disabled"`
+				DataType string `json:"datatype"`
 			} `json:"content"`
 		} `json:"entry"`
 		Paging struct {
@@ -58,6 +60,7 @@ func (c *Client) GetIndexes(ctx context.Context, count, offset int) ([]Index, in
 		indexes[i] = Index{
 			Name:     entry.Name,
 			Disabled: entry.Content.Disabled,
+			DataType: entry.Content.DataType,
 		}
 	}
 
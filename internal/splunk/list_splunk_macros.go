@@ -11,12 +11,14 @@ import (
 type Macro struct {
 	Name       string `json:"name"`
 	Definition string `json:"definition"`
+	Args       string `json:"args,omitempty"`
 	Disabled   bool   `json:"disabled"`
+	ACL
 }
 
-// GetMacros retrieves paginated macros from Splunk
-func (c *Client) GetMacros(ctx context.Context, count, offset int) ([]Macro, int, error) {
-	url := fmt.Sprintf("%s/services/data/macros?output_mode=json&count=%d&offset=%d", c.BaseURL, count, offset)
+// GetMacros retrieves paginated macros from Splunk, filtered and sorted server side
+func (c *Client) GetMacros(ctx context.Context, count, offset int, filter ListFilter) ([]Macro, int, error) {
+	url := fmt.Sprintf("%s/services/data/macros?output_mode=json&count=%d&offset=%d%s", c.BaseURL, count, offset, filter.query("definition"))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -42,8 +44,10 @@ func (c *Client) GetMacros(ctx context.Context, count, offset int) ([]Macro, int
 			Name    string `json:"name"`
 			Content struct {
 				Definition string `json:"definition"`
+				Args       string `json:"args"`
 				Disabled   bool   `json:"disabled"`
 			} `json:"content"`
+			ACL restACL `json:"acl"`
 		} `json:"entry"`
 		Paging struct {
 			Total   int `json:"total"`
@@ -60,9 +64,29 @@ func (c *Client) GetMacros(ctx context.Context, count, offset int) ([]Macro, int
 		macros[i] = Macro{
 			Name:       entry.Name,
 			Definition: entry.Content.Definition,
+			Args:       entry.Content.Args,
 			Disabled:   entry.Content.Disabled,
+			ACL:        entry.ACL.toACL(),
 		}
 	}
 
 	return macros, result.Paging.Total, nil
 }
+
+// getAllMacros pages through GetMacros until every macro has been retrieved
+func (c *Client) getAllMacros(ctx context.Context) ([]Macro, error) {
+	var macros []Macro
+	offset := 0
+	for {
+		batch, total, err := c.GetMacros(ctx, 100, offset, ListFilter{})
+		if err != nil {
+			return nil, err
+		}
+		macros = append(macros, batch...)
+		if offset+100 >= total || len(batch) == 0 {
+			break
+		}
+		offset += 100
+	}
+	return macros, nil
+}
@@ -14,11 +14,12 @@ type SavedSearch struct {
 	Description string `json:"description"`
 	Actions     string `json:"actions"`
 	Disabled    bool   `json:"disabled"`
+	ACL
 }
 
-// GetSavedSearches retrieves paginated saved searches from Splunk
-func (c *Client) GetSavedSearches(ctx context.Context, count, offset int) ([]SavedSearch, int, error) {
-	url := fmt.Sprintf("%s/services/saved/searches?output_mode=json&count=%d&offset=%d", c.BaseURL, count, offset)
+// GetSavedSearches retrieves paginated saved searches from Splunk, filtered and sorted server side
+func (c *Client) GetSavedSearches(ctx context.Context, count, offset int, filter ListFilter) ([]SavedSearch, int, error) {
+	url := fmt.Sprintf("%s/services/saved/searches?output_mode=json&count=%d&offset=%d%s", c.BaseURL, count, offset, filter.query("search"))
 
 	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
@@ -48,6 +49,7 @@ func (c *Client) GetSavedSearches(ctx context.Context, count, offset int) ([]Sav
 				Actions     string `json:"actions"`
 				Disabled    bool   `json:"disabled"`
 			} `json:"content"`
+			ACL restACL `json:"acl"`
 		} `json:"entry"`
 		Paging struct {
 			Total   int `json:"total"`
@@ -67,6 +69,7 @@ func (c *Client) GetSavedSearches(ctx context.Context, count, offset int) ([]Sav
 			Description: entry.Content.Description,
 			Actions:     entry.Content.Actions,
 			Disabled:    entry.Content.Disabled,
+			ACL:         entry.ACL.toACL(),
 		}
 	}
 
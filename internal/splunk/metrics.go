@@ -0,0 +1,144 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// MetricAggregations are the mstats aggregations accepted by QueryMetrics
+var MetricAggregations = []string{"avg", "sum", "min", "max", "count", "latest", "earliest", "median", "perc90", "perc95", "perc99", "rate", "stdev"}
+
+// Metric is a metric name in a metrics index with the dimensions it is reported with.
+type Metric struct {
+	Name       string   `json:"metric_name"`
+	Index      string   `json:"index"`
+	Dimensions []string `json:"dimensions"`
+}
+
+// GetMetrics lists metric names and their dimensions with mcatalog.
+// index and metricName are patterns and may contain wildcards.
+func (c *Client) GetMetrics(ctx context.Context, index, metricName, earliest, latest string, count int) ([]Metric, error) {
+	spl := fmt.Sprintf("| mcatalog values(_dims) as dimensions where index=%s metric_name=%s earliest=%s latest=%s by metric_name index ",
+		QuoteSPL(index), QuoteSPL(metricName), earliest, latest) +
+		fmt.Sprintf("| sort 0 index metric_name | head %d", count)
+
+	rows, err := c.exportSearch(ctx, spl)
+	if err != nil {
+		return nil, err
+	}
+
+	metrics := make([]Metric, len(rows))
+	for i, row := range rows {
+		metrics[i] = Metric{
+			Name:       getString(row, "metric_name"),
+			Index:      getString(row, "index"),
+			Dimensions: getStrings(row, "dimensions"),
+		}
+	}
+	return metrics, nil
+}
+
+// MetricQuery is an mstats query: one aggregation of one metric, optionally filtered, split into time buckets of Span and grouped by dimensions.
+type MetricQuery struct {
+	Index       string
+	Metric      string
+	Aggregation string
+	Span        string
+	GroupBy     []string
+	Filter      string // additional where terms on dimensions, e.g. host=web*
+	Earliest    string
+	Latest      string
+}
+
+// MetricPoint is one time bucket of a series.
+type MetricPoint struct {
+	Time  string   `json:"time"`
+	Value *float64 `json:"value"`
+}
+
+// MetricSeries is the time series of one group-by combination; Group is empty without group-by.
+type MetricSeries struct {
+	Group  map[string]string `json:"group,omitempty"`
+	Points []MetricPoint     `json:"points"`
+}
+
+// MetricResult is the result of an mstats query, one series per group-by combination.
+type MetricResult struct {
+	Query  string         `json:"query"`
+	Series []MetricSeries `json:"series"`
+}
+
+// QueryMetrics runs mstats and returns its time series; at most maxSeries group-by combinations are kept, largest first
+func (c *Client) QueryMetrics(ctx context.Context, q MetricQuery, maxSeries int) (*MetricResult, error) {
+	valid := false
+	for _, a := range MetricAggregations {
+		valid = valid || a == q.Aggregation
+	}
+	if !valid {
+		return nil, fmt.Errorf("unsupported aggregation %q, use one of %s", q.Aggregation, strings.Join(MetricAggregations, ", "))
+	}
+	// a quote or parenthesis in the metric name could end the aggregation early, so they are rejected rather than escaped
+	if strings.ContainsAny(q.Metric, `")`) {
+		return nil, fmt.Errorf("metric name %q must not contain '\"' or ')'", q.Metric)
+	}
+
+	where := "index=" + QuoteSPL(q.Index)
+	if q.Filter != "" {
+		where += " " + q.Filter
+	}
+	spl := fmt.Sprintf("| mstats %s(%s) as value where %s earliest=%s latest=%s", q.Aggregation, QuoteSPL(q.Metric), where, q.Earliest, q.Latest)
+	if len(q.GroupBy) > 0 {
+		spl += " by " + strings.Join(q.GroupBy, " ")
+	}
+	spl += " span=" + q.Span
+
+	rows, err := c.exportSearch(ctx, spl)
+	if err != nil {
+		return nil, err
+	}
+
+	var order []string
+	series := map[string]*MetricSeries{}
+	totals := map[string]float64{}
+	for _, row := range rows {
+		group := map[string]string{}
+		var key []string
+		for _, dim := range q.GroupBy {
+			group[dim] = getString(row, dim)
+			key = append(key, group[dim])
+		}
+		k := strings.Join(key, "\x00")
+		s, ok := series[k]
+		if !ok {
+			s = &MetricSeries{Points: []MetricPoint{}}
+			if len(group) > 0 {
+				s.Group = group
+			}
+			series[k] = s
+			order = append(order, k)
+		}
+
+		point := MetricPoint{Time: getString(row, "_time")}
+		if getString(row, "value") != "" {
+			v := getFloat(row, "value")
+			point.Value = &v
+			totals[k] += v
+		}
+		s.Points = append(s.Points, point)
+	}
+
+	// largest series first, so the cut keeps the most significant ones
+	sort.SliceStable(order, func(i, j int) bool { return totals[order[i]] > totals[order[j]] })
+	if len(order) > maxSeries {
+		order = order[:maxSeries]
+	}
+	result := &MetricResult{Query: spl, Series: make([]MetricSeries, len(order))}
+	for i, k := range order {
+		s := series[k]
+		sort.SliceStable(s.Points, func(a, b int) bool { return s.Points[a].Time < s.Points[b].Time })
+		result.Series[i] = *s
+	}
+	return result, nil
+}
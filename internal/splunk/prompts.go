@@ -29,7 +29,7 @@ func RegisterPrompts(s *server.MCPServer, client *Client) {
 		var alerts []Alert
 		offset := 0
 		for {
-			batch, total, err := client.GetAlerts(ctx, 100, offset, "BT_Alert")
+			batch, total, err := client.GetAlerts(ctx, 100, offset, ListFilter{Name: "BT_Alert"})
 			if err != nil {
 				return nil, fmt.Errorf("failed to get alerts: %w", err)
 			}
@@ -41,25 +41,13 @@ func RegisterPrompts(s *server.MCPServer, client *Client) {
 		}
 
 		// Fetch all macros with pagination
-		var macros []Macro
-		macroOffset := 0
-		for {
-			batch, total, err := client.GetMacros(ctx, 100, macroOffset)
-			if err != nil {
-				return nil, fmt.Errorf("failed to get macros: %w", err)
-			}
-			macros = append(macros, batch...)
-			if macroOffset+100 >= total || len(batch) == 0 {
-				break
-			}
-			macroOffset += 100
-		}
-		macroMap := map[string]string{}
-		for _, macro := range macros {
-			macroMap[macro.Name] = macro.Definition
+		macros, err := client.getAllMacros(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get macros: %w", err)
 		}
 
 		var matchingAlerts []Alert
+		var unexpanded []string
 		for _, alert := range alerts {
 			if strings.Contains(strings.ToLower(alert.Title), keyword) ||
 				strings.Contains(strings.ToLower(alert.Description), keyword) {
@@ -73,13 +61,15 @@ func RegisterPrompts(s *server.MCPServer, client *Client) {
 				continue
 			}
 
-			// Check for macros in the search field (e.g., `macro_name`)
-			for macroName, macroDef := range macroMap {
-				macroPattern := "`" + macroName + "`"
-				if strings.Contains(searchLower, macroPattern) && strings.Contains(strings.ToLower(macroDef), keyword) {
-					matchingAlerts = append(matchingAlerts, alert)
-					break
-				}
+			// Check the search with all macros recursively expanded (e.g., `macro_name`, `macro_name(arg)`)
+			expansion, err := ExpandMacros(alert.Search, macros, alert.App)
+			if err != nil {
+				// e.g. a macro cycle: the alert may still reference the keyword, so it is listed with the error instead of dropped
+				unexpanded = append(unexpanded, fmt.Sprintf("%s: %v", alert.Title, err))
+				continue
+			}
+			if strings.Contains(strings.ToLower(expansion.Expanded), keyword) {
+				matchingAlerts = append(matchingAlerts, alert)
 			}
 		}
 
@@ -88,6 +78,12 @@ func RegisterPrompts(s *server.MCPServer, client *Client) {
 		for _, alert := range matchingAlerts {
 			b.WriteString(fmt.Sprintf("- %s\n", alert.Title))
 		}
+		if len(unexpanded) > 0 {
+			b.WriteString(fmt.Sprintf("\nThe macros of %d alerts could not be expanded, check them manually:\n", len(unexpanded)))
+			for _, line := range unexpanded {
+				b.WriteString(fmt.Sprintf("- %s\n", line))
+			}
+		}
 
 		messages := []mcp.PromptMessage{
 			mcp.NewPromptMessage(
@@ -0,0 +1,189 @@
+package splunk
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Default result budget: about 25k tokens, at roughly 4 bytes per token of JSON
+const (
+	DefaultResultMaxBytes       = 100000
+	DefaultResultMaxFieldLength = 1000
+	BytesPerToken               = 4
+)
+
+// ResultBudget bounds the size of a tool result, so a large listing or search doesn't exceed the client's context.
+// Results within MaxBytes are left untouched; larger ones first get strings longer than MaxFieldLength truncated
+// (e.g. search SPL, _raw), then rows of their largest lists dropped until they fit.
+type ResultBudget struct {
+	MaxBytes       int
+	MaxFieldLength int
+}
+
+// DroppedRows is a list of the result that was cut to fit the budget; NextOffset is set when the result is paginated by offset.
+type DroppedRows struct {
+	Field      string `json:"field"`
+	Kept       int    `json:"kept"`
+	Total      int    `json:"total"`
+	NextOffset *int   `json:"next_offset,omitempty"`
+}
+
+// ResultCut reports what Shape removed from a result.
+type ResultCut struct {
+	MaxBytes        int            `json:"max_bytes"`
+	MaxFieldLength  int            `json:"max_field_length"`
+	TruncatedFields map[string]int `json:"truncated_fields,omitempty"`
+	DroppedRows     []DroppedRows  `json:"dropped_rows,omitempty"`
+}
+
+// Shape marshals v and fits it into the budget; cut is nil when nothing had to be removed
+func (b ResultBudget) Shape(v interface{}) ([]byte, *ResultCut, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, nil, err
+	}
+	if b.MaxBytes <= 0 || len(data) <= b.MaxBytes {
+		return data, nil, nil
+	}
+
+	// work on a generic copy, keeping numbers as they were encoded
+	var tree interface{}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, nil, err
+	}
+
+	cut := &ResultCut{MaxBytes: b.MaxBytes, MaxFieldLength: b.MaxFieldLength, TruncatedFields: map[string]int{}}
+	if b.MaxFieldLength > 0 {
+		tree = b.truncateStrings(tree, "", cut)
+	}
+	if data, err = json.Marshal(tree); err != nil {
+		return nil, nil, err
+	}
+
+	// drop trailing rows of the largest list until the result fits; nested lists are handled in later rounds
+	for round := 0; len(data) > b.MaxBytes && round < 10; round++ {
+		lists := findLists(tree, "")
+		if len(lists) == 0 {
+			break
+		}
+		sort.SliceStable(lists, func(i, j int) bool { return lists[i].size > lists[j].size })
+		list := lists[0]
+
+		available := b.MaxBytes - (len(data) - list.size) - 2
+		kept, used := 0, 0
+		for _, row := range list.rows {
+			rowData, _ := json.Marshal(row)
+			if used+len(rowData)+1 > available {
+				break
+			}
+			used += len(rowData) + 1
+			kept++
+		}
+		if kept == len(list.rows) {
+			break
+		}
+		list.set(list.rows[:kept])
+
+		dropped := DroppedRows{Field: list.path, Kept: kept, Total: len(list.rows)}
+		if top, ok := tree.(map[string]interface{}); ok && !strings.Contains(list.path, ".") {
+			if offset, ok := top["offset"].(json.Number); ok {
+				if n, err := offset.Int64(); err == nil {
+					next := int(n) + kept
+					dropped.NextOffset = &next
+				}
+			}
+		}
+		cut.DroppedRows = append(cut.DroppedRows, dropped)
+
+		if data, err = json.Marshal(tree); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if len(cut.TruncatedFields) == 0 && len(cut.DroppedRows) == 0 {
+		return data, nil, nil
+	}
+	return data, cut, nil
+}
+
+// truncateStrings shortens every string longer than MaxFieldLength, counting truncations per field name
+func (b ResultBudget) truncateStrings(node interface{}, field string, cut *ResultCut) interface{} {
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			v[k] = b.truncateStrings(child, k, cut)
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = b.truncateStrings(child, field, cut)
+		}
+	case string:
+		if s := truncate(v, b.MaxFieldLength); s != v {
+			cut.TruncatedFields[field]++
+			return s
+		}
+	}
+	return node
+}
+
+// resultList is a list inside a result tree with a setter to replace it in its parent
+type resultList struct {
+	path string
+	rows []interface{}
+	size int
+	set  func([]interface{})
+}
+
+// findLists collects the lists with more than one row in a result tree, addressed by dotted path
+func findLists(node interface{}, path string) []resultList {
+	var lists []resultList
+	switch v := node.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			childPath := k
+			if path != "" {
+				childPath = path + "." + k
+			}
+			if rows, ok := child.([]interface{}); ok && len(rows) > 1 {
+				k := k
+				data, _ := json.Marshal(rows)
+				lists = append(lists, resultList{path: childPath, rows: rows, size: len(data), set: func(r []interface{}) { v[k] = r }})
+			}
+			lists = append(lists, findLists(child, childPath)...)
+		}
+	case []interface{}:
+		for i, child := range v {
+			lists = append(lists, findLists(child, fmt.Sprintf("%s[%d]", path, i))...)
+		}
+	}
+	return lists
+}
+
+// Note explains what was cut and how to get the rest
+func (c *ResultCut) Note() string {
+	if c == nil {
+		return ""
+	}
+	var parts []string
+	for _, d := range c.DroppedRows {
+		part := fmt.Sprintf("kept %d of %d %s", d.Kept, d.Total, d.Field)
+		if d.NextOffset != nil {
+			part += fmt.Sprintf(" (continue with offset=%d)", *d.NextOffset)
+		}
+		parts = append(parts, part)
+	}
+	if len(c.TruncatedFields) > 0 {
+		fields := make([]string, 0, len(c.TruncatedFields))
+		for f := range c.TruncatedFields {
+			fields = append(fields, f)
+		}
+		sort.Strings(fields)
+		parts = append(parts, fmt.Sprintf("truncated values longer than %d characters in %s", c.MaxFieldLength, strings.Join(fields, ", ")))
+	}
+	return fmt.Sprintf("Result exceeded the %d byte budget: %s. Use a smaller count, fields or a narrower query to get the rest.", c.MaxBytes, strings.Join(parts, "; "))
+}
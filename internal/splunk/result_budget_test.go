@@ -0,0 +1,147 @@
+package splunk
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func budgetRows(n, size int) []map[string]interface{} {
+	out := make([]map[string]interface{}, n)
+	for i := range out {
+		out[i] = map[string]interface{}{"id": i, "value": strings.Repeat("x", size)}
+	}
+	return out
+}
+
+func TestResultBudgetShape(t *testing.T) {
+	tests := []struct {
+		name       string
+		budget     ResultBudget
+		value      interface{}
+		wantCut    bool
+		wantFields map[string]int
+		wantDrops  []string
+		// wantKept and wantNext describe the first dropped list
+		wantKept int
+		wantNext int
+	}{
+		{
+			name:   "fits unchanged",
+			budget: ResultBudget{MaxBytes: 1000, MaxFieldLength: 10},
+			value:  map[string]interface{}{"search": strings.Repeat("a", 100)},
+		},
+		{
+			name:       "truncation alone is enough",
+			budget:     ResultBudget{MaxBytes: 200, MaxFieldLength: 50},
+			value:      map[string]interface{}{"search": strings.Repeat("a", 300), "name": "short"},
+			wantCut:    true,
+			wantFields: map[string]int{"search": 1},
+		},
+		{
+			name:      "largest list loses rows with next offset",
+			budget:    ResultBudget{MaxBytes: 1000},
+			value:     map[string]interface{}{"offset": 20, "results": budgetRows(20, 100), "fields": []string{"a", "b"}},
+			wantCut:   true,
+			wantDrops: []string{"results"},
+			wantKept:  7,
+			wantNext:  27,
+		},
+		{
+			name:      "nested list has no next offset",
+			budget:    ResultBudget{MaxBytes: 1000},
+			value:     map[string]interface{}{"offset": 0, "results": []interface{}{map[string]interface{}{"rows": budgetRows(20, 100)}}},
+			wantCut:   true,
+			wantDrops: []string{"results[0].rows"},
+			wantKept:  8,
+			wantNext:  -1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, cut, err := tt.budget.Shape(tt.value)
+			if err != nil {
+				t.Fatalf("Shape() error = %v", err)
+			}
+			if !json.Valid(data) {
+				t.Fatalf("Shape() returned invalid JSON: %s", data)
+			}
+			if !tt.wantCut {
+				want, _ := json.Marshal(tt.value)
+				if cut != nil || string(data) != string(want) {
+					t.Errorf("Shape() = %s, %+v, want the value unchanged", data, cut)
+				}
+				return
+			}
+			if cut == nil {
+				t.Fatalf("Shape() cut = nil, want a cut")
+			}
+			if len(data) > tt.budget.MaxBytes {
+				t.Errorf("Shape() returned %d bytes, budget is %d", len(data), tt.budget.MaxBytes)
+			}
+			if len(cut.TruncatedFields)+len(tt.wantFields) > 0 && !reflect.DeepEqual(cut.TruncatedFields, tt.wantFields) {
+				t.Errorf("TruncatedFields = %v, want %v", cut.TruncatedFields, tt.wantFields)
+			}
+			var drops []string
+			for _, d := range cut.DroppedRows {
+				drops = append(drops, d.Field)
+			}
+			if strings.Join(drops, ",") != strings.Join(tt.wantDrops, ",") {
+				t.Fatalf("DroppedRows = %v, want %v", drops, tt.wantDrops)
+			}
+			if len(drops) == 0 {
+				return
+			}
+			first := cut.DroppedRows[0]
+			if first.Kept != tt.wantKept || first.Total != 20 {
+				t.Errorf("kept %d of %d, want %d of 20", first.Kept, first.Total, tt.wantKept)
+			}
+			switch {
+			case tt.wantNext < 0 && first.NextOffset != nil:
+				t.Errorf("NextOffset = %d, want none", *first.NextOffset)
+			case tt.wantNext >= 0 && (first.NextOffset == nil || *first.NextOffset != tt.wantNext):
+				t.Errorf("NextOffset = %v, want %d", first.NextOffset, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestResultBudgetShapeStopsAfterTenRounds(t *testing.T) {
+	value := map[string]interface{}{}
+	for i := 0; i < 12; i++ {
+		value[fmt.Sprintf("list%02d", i)] = budgetRows(2, 100+i)
+	}
+	data, cut, err := ResultBudget{MaxBytes: 100}.Shape(value)
+	if err != nil {
+		t.Fatalf("Shape() error = %v", err)
+	}
+	if cut == nil || len(cut.DroppedRows) != 10 {
+		t.Fatalf("Shape() cut = %+v, want 10 rounds of dropped rows", cut)
+	}
+	// the largest lists go first
+	if cut.DroppedRows[0].Field != "list11" || cut.DroppedRows[9].Field != "list02" {
+		t.Errorf("dropped %s first and %s last, want list11 and list02", cut.DroppedRows[0].Field, cut.DroppedRows[9].Field)
+	}
+	if len(data) <= 100 {
+		t.Errorf("Shape() returned %d bytes, want the result still over budget after the round cap", len(data))
+	}
+}
+
+func TestResultCutNote(t *testing.T) {
+	next := 27
+	cut := &ResultCut{
+		MaxBytes:        1000,
+		MaxFieldLength:  50,
+		TruncatedFields: map[string]int{"search": 2, "_raw": 1},
+		DroppedRows:     []DroppedRows{{Field: "results", Kept: 7, Total: 20, NextOffset: &next}},
+	}
+	want := "Result exceeded the 1000 byte budget: kept 7 of 20 results (continue with offset=27); truncated values longer than 50 characters in _raw, search. Use a smaller count, fields or a narrower query to get the rest."
+	if got := cut.Note(); got != want {
+		t.Errorf("Note() = %q, want %q", got, want)
+	}
+	if got := (*ResultCut)(nil).Note(); got != "" {
+		t.Errorf("nil Note() = %q, want empty", got)
+	}
+}
@@ -0,0 +1,78 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultEventFields are fields Splunk adds to every event; they are left out of SampledEvent.Fields
+var defaultEventFields = map[string]bool{
+	"index": true, "sourcetype": true, "source": true, "host": true, "punct": true, "linecount": true,
+	"splunk_server": true, "splunk_server_group": true, "timestartpos": true, "timeendpos": true,
+	"date_second": true, "date_minute": true, "date_hour": true, "date_mday": true, "date_month": true,
+	"date_wday": true, "date_year": true, "date_zone": true, "pattern_count": true,
+}
+
+// sampleScanLimit bounds the events read for punct deduplication, which has to count every scanned event
+const sampleScanLimit = 100000
+
+// SampledEvent is one raw event with its extracted fields; PatternCount is how many events in the sample share its punct pattern.
+type SampledEvent struct {
+	Time         string                 `json:"time"`
+	Index        string                 `json:"index"`
+	Sourcetype   string                 `json:"sourcetype"`
+	Source       string                 `json:"source"`
+	Host         string                 `json:"host"`
+	Punct        string                 `json:"punct,omitempty"`
+	PatternCount int                    `json:"pattern_count,omitempty"`
+	Raw          string                 `json:"raw"`
+	RawTruncated bool                   `json:"raw_truncated,omitempty"`
+	Fields       map[string]interface{} `json:"fields"`
+}
+
+// SampleEvents returns up to count raw events of a search with _raw truncated to rawLength runes.
+// sampleRatio > 1 lets Splunk read only 1 in sampleRatio events, which keeps sampling large indexes cheap;
+// with dedupPunct only one event per punct pattern of the first sampleScanLimit events is kept, showing structural variety instead of repeats.
+func (c *Client) SampleEvents(ctx context.Context, spl, earliest, latest string, count, rawLength, sampleRatio int, dedupPunct bool) ([]SampledEvent, error) {
+	spl = searchCommand(spl)
+	if dedupPunct {
+		spl += fmt.Sprintf(" | head %d | eventstats count as pattern_count by punct | dedup punct | sort 0 - pattern_count", sampleScanLimit)
+	}
+	spl += fmt.Sprintf(" | head %d | table _time _raw index sourcetype source host punct *", count)
+
+	form := jobForm(spl, earliest, latest)
+	if sampleRatio > 1 {
+		form.Set("sample_ratio", strconv.Itoa(sampleRatio))
+	}
+	_, rows, err := c.runJob(ctx, form, count)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]SampledEvent, len(rows))
+	for i, row := range rows {
+		raw := getString(row, "_raw")
+		event := SampledEvent{
+			Time:         getString(row, "_time"),
+			Index:        getString(row, "index"),
+			Sourcetype:   getString(row, "sourcetype"),
+			Source:       getString(row, "source"),
+			Host:         getString(row, "host"),
+			Punct:        getString(row, "punct"),
+			PatternCount: getInt(row, "pattern_count"),
+			Raw:          truncate(raw, rawLength),
+			Fields:       map[string]interface{}{},
+		}
+		event.RawTruncated = event.Raw != raw
+		for k, v := range row {
+			if strings.HasPrefix(k, "_") || defaultEventFields[k] {
+				continue
+			}
+			event.Fields[k] = v
+		}
+		events[i] = event
+	}
+	return events, nil
+}
@@ -0,0 +1,306 @@
+package splunk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FieldChange is one setting changed by a write operation; Before is empty for new settings, After for removed ones.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// WritePreview describes a write operation. A dry run returns it with Applied=false; applying the operation
+// requires passing its PreviewToken back, which proves the caller saw this exact diff against the current state.
+type WritePreview struct {
+	Operation    string        `json:"operation"`
+	Name         string        `json:"name"`
+	App          string        `json:"app"`
+	Changes      []FieldChange `json:"changes"`
+	PreviewToken string        `json:"preview_token"`
+	Applied      bool          `json:"applied"`
+}
+
+// savedSearchSummaryKeys are the settings shown in the diff of a delete and copied first by a clone
+var savedSearchSummaryKeys = []string{
+	"search", "description", "cron_schedule", "is_scheduled", "dispatch.earliest_time", "dispatch.latest_time",
+	"actions", "alert_type", "alert_comparator", "alert_threshold", "alert_condition", "disabled",
+}
+
+// CreateSavedSearch previews or creates a saved search in app (shared at app level, owner nobody)
+func (c *Client) CreateSavedSearch(ctx context.Context, name, app string, settings map[string]string, dryRun bool, previewToken string) (*WritePreview, error) {
+	if err := validateSavedSearchSettings(settings); err != nil {
+		return nil, err
+	}
+	if settings["search"] == "" {
+		return nil, fmt.Errorf("search is required to create a saved search")
+	}
+	if err := c.checkSavedSearchAbsent(ctx, name, app); err != nil {
+		return nil, err
+	}
+
+	preview := newWritePreview("create", name, app, diffSettings(nil, settings))
+	return preview, c.applyWrite(preview, dryRun, previewToken, func() error {
+		form := settingsForm(settings)
+		form.Set("name", name)
+		return c.doForm(ctx, "POST", fmt.Sprintf("/servicesNS/nobody/%s/saved/searches", url.PathEscape(app)), form, nil)
+	})
+}
+
+// UpdateSavedSearch previews or applies changed settings (search, schedule, actions, ...) of an existing saved search
+func (c *Client) UpdateSavedSearch(ctx context.Context, name, app string, settings map[string]string, dryRun bool, previewToken string) (*WritePreview, error) {
+	if err := validateSavedSearchSettings(settings); err != nil {
+		return nil, err
+	}
+	if len(settings) == 0 {
+		return nil, fmt.Errorf("no settings to update")
+	}
+	entry, err := c.getSavedSearchEntry(ctx, name, app)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := newWritePreview("update", name, entry.ACL.App, diffSettings(entry.Content, settings))
+	return preview, c.applyWrite(preview, dryRun, previewToken, func() error {
+		return c.doForm(ctx, "POST", savedSearchPath(entry), settingsForm(settings), nil)
+	})
+}
+
+// CloneSavedSearch previews or creates a copy of a saved search under a new name (optionally in another app), with optional setting overrides
+func (c *Client) CloneSavedSearch(ctx context.Context, name, app, newName, newApp string, overrides map[string]string, dryRun bool, previewToken string) (*WritePreview, error) {
+	source, err := c.getSavedSearchEntry(ctx, name, app)
+	if err != nil {
+		return nil, err
+	}
+	if newApp == "" {
+		newApp = source.ACL.App
+	}
+	if err := c.checkSavedSearchAbsent(ctx, newName, newApp); err != nil {
+		return nil, err
+	}
+
+	settings := cloneableSettings(source.Content)
+	for k, v := range overrides {
+		settings[k] = v
+	}
+	// the copied settings go through the same allowlist as explicit ones
+	if err := validateSavedSearchSettings(settings); err != nil {
+		return nil, err
+	}
+
+	preview := newWritePreview("clone", newName, newApp, diffSettings(nil, settings))
+	return preview, c.applyWrite(preview, dryRun, previewToken, func() error {
+		form := settingsForm(settings)
+		form.Set("name", newName)
+		return c.doForm(ctx, "POST", fmt.Sprintf("/servicesNS/nobody/%s/saved/searches", url.PathEscape(newApp)), form, nil)
+	})
+}
+
+// checkSavedSearchAbsent fails unless Splunk confirms no saved search name exists in app; a failed lookup is not taken as absence.
+// It looks in the nobody/app namespace the write posts into, where objects shared globally from other apps are visible too
+// but do not collide, so only entries of app itself count.
+func (c *Client) checkSavedSearchAbsent(ctx context.Context, name, app string) error {
+	entries, _, err := c.getRESTEntries(ctx, fmt.Sprintf("/servicesNS/nobody/%s/saved/searches/%s", url.PathEscape(app), url.PathEscape(name)), url.Values{})
+	if err != nil && !errors.Is(err, errNotFound) {
+		return fmt.Errorf("failed to check whether saved search %q exists: %w", name, err)
+	}
+	for _, entry := range entries {
+		if entry.ACL.App == app {
+			return fmt.Errorf("saved search %q already exists in app %s", name, app)
+		}
+	}
+	return nil
+}
+
+// DeleteSavedSearch previews or deletes a saved search; the diff lists the main settings being removed
+func (c *Client) DeleteSavedSearch(ctx context.Context, name, app string, dryRun bool, previewToken string) (*WritePreview, error) {
+	entry, err := c.getSavedSearchEntry(ctx, name, app)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes []FieldChange
+	for _, key := range savedSearchSummaryKeys {
+		if before := contentString(entry.Content[key]); before != "" {
+			changes = append(changes, FieldChange{Field: key, Before: before})
+		}
+	}
+
+	preview := newWritePreview("delete", name, entry.ACL.App, changes)
+	return preview, c.applyWrite(preview, dryRun, previewToken, func() error {
+		return c.doForm(ctx, "DELETE", savedSearchPath(entry), nil, nil)
+	})
+}
+
+// applyWrite runs apply unless this is a dry run, after checking the caller previewed exactly this change, and audits the outcome
+func (c *Client) applyWrite(preview *WritePreview, dryRun bool, previewToken string, apply func() error) error {
+	entry := AuditEntry{
+		Operation: preview.Operation + "_saved_search",
+		Object:    preview.Name,
+		App:       preview.App,
+		DryRun:    dryRun,
+		Changes:   preview.Changes,
+	}
+
+	if dryRun {
+		entry.Result = "previewed"
+		c.Audit.Record(entry)
+		return nil
+	}
+
+	if previewToken != preview.PreviewToken {
+		err := fmt.Errorf("preview_token does not match the current change; run with dry_run=true, review the diff and pass its preview_token")
+		entry.Result = "rejected"
+		entry.Error = err.Error()
+		c.Audit.Record(entry)
+		return err
+	}
+
+	if err := apply(); err != nil {
+		entry.Result = "failed"
+		entry.Error = err.Error()
+		c.Audit.Record(entry)
+		return err
+	}
+
+	entry.Result = "applied"
+	c.Audit.Record(entry)
+	preview.Applied = true
+	return nil
+}
+
+func newWritePreview(operation, name, app string, changes []FieldChange) *WritePreview {
+	if changes == nil {
+		changes = []FieldChange{}
+	}
+	preview := &WritePreview{Operation: operation, Name: name, App: app, Changes: changes}
+
+	// The token binds the operation to the diff, so it is invalidated by different arguments or a concurrent change in Splunk
+	data, _ := json.Marshal(preview)
+	sum := sha256.Sum256(data)
+	preview.PreviewToken = hex.EncodeToString(sum[:8])
+	return preview
+}
+
+// diffSettings compares the new settings against the current content (nil for a new object), sorted by field
+func diffSettings(current map[string]interface{}, settings map[string]string) []FieldChange {
+	var changes []FieldChange
+	for key, after := range settings {
+		before := contentString(current[key])
+		if before != after {
+			changes = append(changes, FieldChange{Field: key, Before: before, After: after})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Field < changes[j].Field })
+	return changes
+}
+
+// cloneableSettings copies the summary settings, alert settings and parameters of enabled actions from a saved search.
+// disabled is not copied, so a clone of a disabled search starts enabled.
+func cloneableSettings(content map[string]interface{}) map[string]string {
+	settings := map[string]string{}
+	for _, key := range savedSearchSummaryKeys {
+		if key == "disabled" {
+			continue
+		}
+		if v := contentString(content[key]); v != "" {
+			settings[key] = v
+		}
+	}
+
+	var prefixes []string
+	prefixes = append(prefixes, "alert.")
+	for _, action := range strings.Split(getString(content, "actions"), ",") {
+		if action = strings.TrimSpace(action); action != "" {
+			prefixes = append(prefixes, "action."+action)
+		}
+	}
+	for key, value := range content {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				if v := contentString(value); v != "" {
+					settings[key] = v
+				}
+				break
+			}
+		}
+	}
+	return settings
+}
+
+// writableSettings and writableSettingPrefixes allowlist the settings the write tools may set:
+// schedule, dispatch window, alert condition, suppression (alert.*) and action parameters (action.*).
+// disabled is left to enable/disable_splunk_saved_search.
+var (
+	writableSettings = map[string]bool{
+		"search": true, "description": true, "cron_schedule": true, "is_scheduled": true, "schedule_window": true,
+		"schedule_priority": true, "realtime_schedule": true, "dispatch.earliest_time": true, "dispatch.latest_time": true,
+		"dispatch.ttl": true, "actions": true, "alert_type": true, "alert_comparator": true, "alert_threshold": true,
+		"alert_condition": true, "counttype": true, "relation": true, "quantity": true,
+	}
+	writableSettingPrefixes = []string{"alert.", "action."}
+)
+
+// IsWritableSavedSearchSetting reports whether the write tools may set a saved search setting
+func IsWritableSavedSearchSetting(key string) bool {
+	if writableSettings[key] {
+		return true
+	}
+	for _, prefix := range writableSettingPrefixes {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateSavedSearchSettings(settings map[string]string) error {
+	for key := range settings {
+		if !IsWritableSavedSearchSetting(key) {
+			return fmt.Errorf("setting %q cannot be changed", key)
+		}
+	}
+	return nil
+}
+
+func settingsForm(settings map[string]string) url.Values {
+	form := url.Values{}
+	for k, v := range settings {
+		form.Set(k, v)
+	}
+	return form
+}
+
+// savedSearchPath is the REST path of a saved search in its owner's and app's namespace
+func savedSearchPath(entry *restEntry) string {
+	return fmt.Sprintf("/servicesNS/%s/%s/saved/searches/%s",
+		url.PathEscape(entry.ACL.Owner), url.PathEscape(entry.ACL.App), url.PathEscape(entry.Name))
+}
+
+// contentString renders a REST content value the way Splunk accepts it back as a setting
+func contentString(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case bool:
+		if v {
+			return "1"
+		}
+		return "0"
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+	return fmt.Sprintf("%v", v)
+}
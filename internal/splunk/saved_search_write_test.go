@@ -0,0 +1,104 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestCreateSavedSearchOnlyProceedsWhenAbsent(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		entryApp string
+		wantErr  string
+	}{
+		{"absent", http.StatusNotFound, "", ""},
+		{"exists", http.StatusOK, "search", "already exists"},
+		{"shared globally from another app", http.StatusOK, "okta_app", ""},
+		{"lookup fails", http.StatusInternalServerError, "", "failed to check"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != "GET" {
+					t.Errorf("unexpected %s %s during a dry run", r.Method, r.URL.Path)
+				}
+				if r.URL.Path != "/servicesNS/nobody/search/saved/searches/okta" {
+					t.Errorf("unexpected lookup %s, want the namespace the search is created in", r.URL.Path)
+				}
+				w.WriteHeader(tt.status)
+				if tt.status == http.StatusOK {
+					fmt.Fprintf(w, `{"entry": [{"name": "okta", "content": {}, "acl": {"app": %q, "owner": "nobody", "sharing": "global"}}]}`, tt.entryApp)
+				}
+			}))
+			defer srv.Close()
+
+			client := NewClient(srv.URL, "token")
+			preview, err := client.CreateSavedSearch(context.Background(), "okta", "search", map[string]string{"search": "index=okta"}, true, "")
+			if tt.wantErr == "" {
+				if err != nil || preview == nil || preview.Applied {
+					t.Fatalf("CreateSavedSearch() = %+v, %v, want an unapplied preview", preview, err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("CreateSavedSearch() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCloneSavedSearchChecksCopiedSettings(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/servicesNS/-/search/saved/searches/okta" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"entry": [{"name": "okta", "acl": {"app": "search", "owner": "nobody"}, "content": {
+			"search": "index=okta", "disabled": true, "actions": "email", "action.email": true, "action.email.to": "soc@example.com",
+			"action.webhook.param.url": "https://example.com", "alert.suppress": true}}]}`))
+	}))
+	defer srv.Close()
+	client := NewClient(srv.URL, "token")
+
+	preview, err := client.CloneSavedSearch(context.Background(), "okta", "search", "okta copy", "", nil, true, "")
+	if err != nil {
+		t.Fatalf("CloneSavedSearch() error = %v", err)
+	}
+	copied := map[string]string{}
+	for _, change := range preview.Changes {
+		copied[change.Field] = change.After
+	}
+	want := map[string]string{"search": "index=okta", "actions": "email", "action.email": "1", "action.email.to": "soc@example.com", "alert.suppress": "1"}
+	if !reflect.DeepEqual(copied, want) {
+		t.Errorf("clone copies %v, want %v", copied, want)
+	}
+
+	if _, err := client.CloneSavedSearch(context.Background(), "okta", "search", "okta copy", "", map[string]string{"disabled": "1"}, true, ""); err == nil {
+		t.Errorf("CloneSavedSearch() with a disabled override succeeded, want the allowlist to reject it")
+	}
+}
+
+func TestIsWritableSavedSearchSetting(t *testing.T) {
+	tests := map[string]bool{
+		"search":                true,
+		"cron_schedule":         true,
+		"alert.suppress.fields": true,
+		"action.email.to":       true,
+		"disabled":              false,
+		"name":                  false,
+		"eai:acl.owner":         false,
+		"action.":               false,
+		"request.ui_dispatch":   false,
+	}
+	for key, want := range tests {
+		if got := IsWritableSavedSearchSetting(key); got != want {
+			t.Errorf("IsWritableSavedSearchSetting(%q) = %v, want %v", key, got, want)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+)
+
+// SchedulerHealth summarizes the scheduler activity of one saved search over a time range.
+// Failed counts every run that was neither successful, skipped, deferred nor continued.
+type SchedulerHealth struct {
+	SavedSearch string   `json:"savedsearch_name"`
+	App         string   `json:"app"`
+	Runs        int      `json:"runs"`
+	Success     int      `json:"success"`
+	Skipped     int      `json:"skipped"`
+	Deferred    int      `json:"deferred"`
+	Failed      int      `json:"failed"`
+	SkipReasons []string `json:"skip_reasons,omitempty"`
+	AvgRunTime  float64  `json:"avg_run_time_seconds"`
+	LastRun     string   `json:"last_run"`
+	LastSuccess string   `json:"last_success,omitempty"`
+}
+
+// GetSchedulerHealth summarizes index=_internal sourcetype=scheduler per saved search, worst (most skipped, deferred and failed) first.
+// With problemsOnly, saved searches that always ran successfully are left out.
+func (c *Client) GetSchedulerHealth(ctx context.Context, count int, ssName, earliest, latest string, problemsOnly bool) ([]SchedulerHealth, error) {
+	spl := fmt.Sprintf("search index=_internal sourcetype=scheduler savedsearch_name=%s earliest=%s latest=%s ", QuoteSPL(ssName), earliest, latest) +
+		"| eval outcome=case(status=\"success\", \"success\", status=\"skipped\", \"skipped\", status=\"deferred\", \"deferred\", " +
+		"status=\"continued\" OR (like(status, \"delegated_remote%\") AND status!=\"delegated_remote_error\"), \"other\", true(), \"failed\") " +
+		"| stats count as runs, count(eval(outcome=\"success\")) as success, count(eval(outcome=\"skipped\")) as skipped, " +
+		"count(eval(outcome=\"deferred\")) as deferred, count(eval(outcome=\"failed\")) as failed, " +
+		"values(eval(if(outcome=\"skipped\", reason, null()))) as skip_reasons, avg(run_time) as avg_run_time, " +
+		"max(_time) as last_run, max(eval(if(outcome=\"success\", _time, null()))) as last_success by savedsearch_name app "
+	if problemsOnly {
+		spl += "| where skipped > 0 OR deferred > 0 OR failed > 0 "
+	}
+	spl += fmt.Sprintf("| eval problems=skipped+deferred+failed, avg_run_time=round(avg_run_time, 2), "+
+		"last_run=strftime(last_run, \"%%Y-%%m-%%dT%%H:%%M:%%S%%z\"), last_success=strftime(last_success, \"%%Y-%%m-%%dT%%H:%%M:%%S%%z\") "+
+		"| sort 0 - problems, - runs | head %d", count)
+
+	rows, err := c.exportSearch(ctx, spl)
+	if err != nil {
+		return nil, err
+	}
+
+	health := make([]SchedulerHealth, len(rows))
+	for i, row := range rows {
+		health[i] = SchedulerHealth{
+			SavedSearch: getString(row, "savedsearch_name"),
+			App:         getString(row, "app"),
+			Runs:        getInt(row, "runs"),
+			Success:     getInt(row, "success"),
+			Skipped:     getInt(row, "skipped"),
+			Deferred:    getInt(row, "deferred"),
+			Failed:      getInt(row, "failed"),
+			SkipReasons: getStrings(row, "skip_reasons"),
+			AvgRunTime:  getFloat(row, "avg_run_time"),
+			LastRun:     getString(row, "last_run"),
+			LastSuccess: getString(row, "last_success"),
+		}
+	}
+	return health, nil
+}
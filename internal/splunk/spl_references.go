@@ -0,0 +1,147 @@
+package splunk
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Knowledge object types referenced from SPL and used as node types of the dependency graph
+const (
+	ObjectSavedSearch = "savedsearch"
+	ObjectAlert       = "alert"
+	ObjectMacro       = "macro"
+	ObjectEventtype   = "eventtype"
+	ObjectLookup      = "lookup"
+	ObjectDashboard   = "dashboard"
+	// ObjectAutoLookup is a props.conf LOOKUP-* binding, which applies a lookup to matching events without any SPL reference
+	ObjectAutoLookup = "automatic_lookup"
+)
+
+// objectRef is a reference from SPL (or dashboard XML) to another knowledge object.
+// Saved searches and alerts share the ObjectSavedSearch type, since SPL cannot tell them apart.
+// App and Owner are only set when the reference names them, as `| loadjob savedsearch="owner:app:name"` does.
+type objectRef struct {
+	Type  string
+	Name  string
+	App   string
+	Owner string
+}
+
+var (
+	eventtypeRefPattern = regexp.MustCompile(`(?i)\beventtype\s*=\s*(?:"([^"]+)"|([^\s|)\]]+))`)
+	lookupCmdPattern    = regexp.MustCompile(`(?i)(?:^|[|\[])\s*(?:lookup|inputlookup|outputlookup)\s+`)
+	savedsearchCmd      = regexp.MustCompile(`(?i)(?:^|[|\[])\s*savedsearch\s+`)
+	loadjobRefPattern   = regexp.MustCompile(`(?i)\bloadjob\s+savedsearch\s*=\s*"?([^"\s|]+)"?`)
+	dashboardQuery      = regexp.MustCompile(`(?s)<query>(.*?)</query>`)
+	dashboardSearchRef  = regexp.MustCompile(`<search[^>]*\sref="([^"]+)"`)
+	dashboardJSONQuery  = regexp.MustCompile(`"query"\s*:\s*("(?:\\.|[^"\\])*")`)
+)
+
+// splReferences extracts the macros, eventtypes, lookups and saved searches referenced by spl
+func splReferences(spl string) []objectRef {
+	seen := map[objectRef]bool{}
+	var refs []objectRef
+	addRef := func(ref objectRef) {
+		if ref.Name == "" || seen[ref] {
+			return
+		}
+		seen[ref] = true
+		refs = append(refs, ref)
+	}
+	add := func(t, name string) {
+		addRef(objectRef{Type: t, Name: name})
+	}
+
+	// `macro` and `macro(args)`
+	rest := spl
+	for {
+		start := strings.IndexByte(rest, '`')
+		if start < 0 {
+			break
+		}
+		end := strings.IndexByte(rest[start+1:], '`')
+		if end < 0 {
+			break
+		}
+		name, args := parseMacroCall(rest[start+1 : start+1+end])
+		if args != nil {
+			name = fmt.Sprintf("%s(%d)", name, len(args))
+		}
+		add(ObjectMacro, name)
+		rest = rest[start+1+end+1:]
+	}
+
+	for _, m := range eventtypeRefPattern.FindAllStringSubmatch(spl, -1) {
+		add(ObjectEventtype, m[1]+m[2])
+	}
+	for _, loc := range lookupCmdPattern.FindAllStringIndex(spl, -1) {
+		add(ObjectLookup, firstArgument(spl[loc[1]:]))
+	}
+	for _, loc := range savedsearchCmd.FindAllStringIndex(spl, -1) {
+		add(ObjectSavedSearch, firstArgument(spl[loc[1]:]))
+	}
+	for _, m := range loadjobRefPattern.FindAllStringSubmatch(spl, -1) {
+		// savedsearch="owner:app:name"
+		ref := objectRef{Type: ObjectSavedSearch}
+		if parts := strings.Split(m[1], ":"); len(parts) == 3 {
+			ref.Owner, ref.App, ref.Name = parts[0], parts[1], parts[2]
+		} else {
+			ref.Name = parts[len(parts)-1]
+		}
+		addRef(ref)
+	}
+
+	return refs
+}
+
+// firstArgument returns the first non-option argument of a command (options are key=value tokens)
+func firstArgument(s string) string {
+	for {
+		s = strings.TrimLeft(s, " \t\r\n")
+		if s == "" || s[0] == '|' || s[0] == ']' {
+			return ""
+		}
+		if s[0] == '"' {
+			if end := strings.IndexByte(s[1:], '"'); end >= 0 {
+				return s[1 : end+1]
+			}
+			return ""
+		}
+		end := strings.IndexAny(s, " \t\r\n|]")
+		if end < 0 {
+			end = len(s)
+		}
+		token := s[:end]
+		if !strings.Contains(token, "=") {
+			return token
+		}
+		s = s[end:]
+	}
+}
+
+// dashboardReferences extracts the searches of a Simple XML or Dashboard Studio source:
+// the inline SPL queries and the names of referenced saved searches (<search ref="...">)
+func dashboardReferences(source string) ([]string, []string) {
+	var queries []string
+	for _, m := range dashboardQuery.FindAllStringSubmatch(source, -1) {
+		q := strings.TrimSpace(m[1])
+		q = strings.TrimPrefix(q, "<![CDATA[")
+		q = strings.TrimSuffix(q, "]]>")
+		queries = append(queries, html.UnescapeString(q))
+	}
+	for _, m := range dashboardJSONQuery.FindAllStringSubmatch(source, -1) {
+		var q string
+		if err := json.Unmarshal([]byte(m[1]), &q); err == nil {
+			queries = append(queries, q)
+		}
+	}
+
+	var savedSearches []string
+	for _, m := range dashboardSearchRef.FindAllStringSubmatch(source, -1) {
+		savedSearches = append(savedSearches, html.UnescapeString(m[1]))
+	}
+	return queries, savedSearches
+}
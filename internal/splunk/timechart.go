@@ -0,0 +1,169 @@
+package splunk
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// maxTimechartRows bounds the rows fetched from a timechart job, one per time bucket
+const maxTimechartRows = 10000
+
+// TimeSeries is one named series of a timechart; Values align with Timechart.Timestamps and are null for empty buckets.
+type TimeSeries struct {
+	Name   string     `json:"name"`
+	Values []*float64 `json:"values"`
+}
+
+// Timechart is timechart output normalized from wide rows into timestamps plus one array per series.
+// SpanSeconds is the bucket size after downsampling; DownsampledBy is how many original buckets were merged into one.
+// Truncated is set when the search reached maxTimechartRows buckets, so later buckets may be missing.
+type Timechart struct {
+	Search        string       `json:"search"`
+	SpanSeconds   int          `json:"span_seconds"`
+	Points        int          `json:"points"`
+	DownsampledBy int          `json:"downsampled_by,omitempty"`
+	Aggregate     string       `json:"aggregate,omitempty"`
+	Truncated     bool         `json:"truncated,omitempty"`
+	Timestamps    []string     `json:"timestamps"`
+	Series        []TimeSeries `json:"series"`
+}
+
+// GetTimechart runs a timechart-style search (one row per _time bucket) and returns it as named series.
+// The pipeline is parsed by Splunk first; a search without a timechart command (e.g. stats by _time) must return _time in every row.
+// With more than maxPoints buckets, consecutive buckets are merged with aggregate (avg, sum, min, max or last).
+func (c *Client) GetTimechart(ctx context.Context, spl, earliest, latest string, maxPoints int, aggregate string) (*Timechart, error) {
+	parsed, err := c.parseSearch(ctx, searchCommand(spl))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse search: %w", err)
+	}
+	hasTimechart := false
+	for _, command := range parsedCommands(parsed) {
+		hasTimechart = hasTimechart || command == "timechart"
+	}
+	if aggregate == "" {
+		aggregate = "avg"
+	}
+	_, rows, err := c.RunJob(ctx, spl, earliest, latest, maxTimechartRows)
+	if err != nil {
+		return nil, err
+	}
+	if !hasTimechart {
+		for _, row := range rows {
+			if getString(row, "_time") == "" {
+				return nil, fmt.Errorf("search must return one row per _time bucket, e.g. end with | timechart")
+			}
+		}
+	}
+
+	chart := &Timechart{Search: spl, Timestamps: []string{}, Series: []TimeSeries{}, Truncated: len(rows) >= maxTimechartRows}
+	names := map[string]bool{}
+	for _, row := range rows {
+		for k := range row {
+			if !strings.HasPrefix(k, "_") {
+				names[k] = true
+			}
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	for _, name := range sorted {
+		chart.Series = append(chart.Series, TimeSeries{Name: name, Values: make([]*float64, 0, len(rows))})
+	}
+	for _, row := range rows {
+		chart.Timestamps = append(chart.Timestamps, getString(row, "_time"))
+		for i, name := range sorted {
+			var value *float64
+			if getString(row, name) != "" {
+				v := getFloat(row, name)
+				value = &v
+			}
+			chart.Series[i].Values = append(chart.Series[i].Values, value)
+		}
+	}
+
+	chart.SpanSeconds = getInt(firstRow(rows), "_span")
+	if chart.SpanSeconds == 0 && len(rows) > 1 {
+		t0, err0 := time.Parse(time.RFC3339Nano, chart.Timestamps[0])
+		t1, err1 := time.Parse(time.RFC3339Nano, chart.Timestamps[1])
+		if err0 == nil && err1 == nil {
+			chart.SpanSeconds = int(t1.Sub(t0).Seconds())
+		}
+	}
+
+	if maxPoints > 0 && len(chart.Timestamps) > maxPoints {
+		factor := int(math.Ceil(float64(len(chart.Timestamps)) / float64(maxPoints)))
+		chart.downsample(factor, aggregate)
+	}
+	chart.Points = len(chart.Timestamps)
+	return chart, nil
+}
+
+// downsample merges every factor consecutive buckets into one, timestamped with the first bucket
+func (t *Timechart) downsample(factor int, aggregate string) {
+	var timestamps []string
+	for i := 0; i < len(t.Timestamps); i += factor {
+		timestamps = append(timestamps, t.Timestamps[i])
+	}
+	for s := range t.Series {
+		values := t.Series[s].Values
+		var merged []*float64
+		for i := 0; i < len(values); i += factor {
+			end := i + factor
+			if end > len(values) {
+				end = len(values)
+			}
+			merged = append(merged, mergeValues(values[i:end], aggregate))
+		}
+		t.Series[s].Values = merged
+	}
+	t.Timestamps = timestamps
+	t.SpanSeconds *= factor
+	t.DownsampledBy = factor
+	t.Aggregate = aggregate
+}
+
+// mergeValues aggregates bucket values, ignoring nulls; all-null buckets stay null
+func mergeValues(values []*float64, aggregate string) *float64 {
+	var result float64
+	n := 0
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		switch {
+		case n == 0:
+			result = *v
+		case aggregate == "min":
+			result = math.Min(result, *v)
+		case aggregate == "max":
+			result = math.Max(result, *v)
+		case aggregate == "last":
+			result = *v
+		default:
+			result += *v
+		}
+		n++
+	}
+	if n == 0 {
+		return nil
+	}
+	if aggregate == "avg" {
+		result /= float64(n)
+	}
+	return &result
+}
+
+func firstRow(rows []map[string]interface{}) map[string]interface{} {
+	if len(rows) == 0 {
+		return nil
+	}
+	return rows[0]
+}
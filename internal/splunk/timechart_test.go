@@ -0,0 +1,92 @@
+package splunk
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// timechartServer fakes the search parser and a search job returning rows
+func timechartServer(t *testing.T, commands []string, rows []map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/services/search/parser":
+			var parsed []map[string]string
+			for _, command := range commands {
+				parsed = append(parsed, map[string]string{"command": command})
+			}
+			json.NewEncoder(w).Encode(map[string]interface{}{"commands": parsed})
+		case r.Method == "POST" && r.URL.Path == "/services/search/jobs":
+			json.NewEncoder(w).Encode(map[string]string{"sid": "tc"})
+		case r.Method == "GET" && r.URL.Path == "/services/search/jobs/tc":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"entry": []map[string]interface{}{{"name": "tc", "content": map[string]interface{}{"isDone": true, "resultCount": len(rows)}}},
+			})
+		case r.URL.Path == "/services/search/jobs/tc/results":
+			json.NewEncoder(w).Encode(map[string]interface{}{"results": rows})
+		case r.Method == "DELETE":
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+	}))
+}
+
+func TestGetTimechartChecksThePipeline(t *testing.T) {
+	bucketed := []map[string]interface{}{
+		{"_time": "2026-01-01T00:00:00.000+00:00", "_span": "3600", "count": "3"},
+		{"_time": "2026-01-01T01:00:00.000+00:00", "_span": "3600", "count": "5"},
+	}
+	tests := []struct {
+		name     string
+		spl      string
+		commands []string
+		rows     []map[string]interface{}
+		wantErr  bool
+	}{
+		{"timechart", "index=main | timechart span=1h count", []string{"search", "timechart"}, bucketed, false},
+		{"stats by _time", "index=main | bin _time span=1h | stats count by _time", []string{"search", "bin", "stats"}, bucketed, false},
+		{"timechart only in a string", `index=main | eval x="timechart" | stats count`, []string{"search", "eval", "stats"}, []map[string]interface{}{{"count": "8"}}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := timechartServer(t, tt.commands, tt.rows)
+			defer srv.Close()
+
+			chart, err := NewClient(srv.URL, "token").GetTimechart(context.Background(), tt.spl, "-2h", "now", 0, "")
+			if tt.wantErr {
+				if err == nil || !strings.Contains(err.Error(), "one row per _time bucket") {
+					t.Errorf("GetTimechart() error = %v, want a _time bucket error", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("GetTimechart() error = %v", err)
+			}
+			if chart.Points != 2 || chart.SpanSeconds != 3600 || len(chart.Series) != 1 || *chart.Series[0].Values[1] != 5 {
+				t.Errorf("unexpected chart %+v", chart)
+			}
+		})
+	}
+}
+
+func TestGetTimechartFlagsTruncatedSeries(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	rows := make([]map[string]interface{}, maxTimechartRows)
+	for i := range rows {
+		rows[i] = map[string]interface{}{"_time": start.Add(time.Duration(i) * time.Minute).Format(time.RFC3339), "_span": "60", "count": "1"}
+	}
+	srv := timechartServer(t, []string{"search", "timechart"}, rows)
+	defer srv.Close()
+
+	chart, err := NewClient(srv.URL, "token").GetTimechart(context.Background(), "index=main | timechart span=1m count", "-30d", "now", 200, "")
+	if err != nil {
+		t.Fatalf("GetTimechart() error = %v", err)
+	}
+	if !chart.Truncated || chart.Points != 200 {
+		t.Errorf("got truncated=%v with %d points, want truncated with 200 points", chart.Truncated, chart.Points)
+	}
+}
@@ -0,0 +1,43 @@
+package splunk
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestUnusedObjectsKeepsSameNamedObjectsApart(t *testing.T) {
+	g := newDependencyGraph()
+	search := g.addNode(ObjectSavedSearch, "failed logins", restACL{App: "sec", Owner: "nobody", Sharing: "app"})
+	g.addNode(ObjectMacro, "auth", restACL{App: "sec", Owner: "nobody", Sharing: "app"})
+	g.addNode(ObjectMacro, "auth", restACL{App: "ops", Owner: "nobody", Sharing: "app"})
+	g.addNode(ObjectEventtype, "vpn", restACL{App: "ops", Owner: "dave", Sharing: "user"})
+	g.addNode(ObjectLookup, "assets", restACL{App: "ops", Owner: "nobody", Sharing: "global"})
+	g.addNode(ObjectLookup, "old_assets", restACL{App: "ops", Owner: "nobody", Sharing: "app"})
+	autoLookup := g.addNode(ObjectAutoLookup, "syslog : LOOKUP-assets", restACL{App: "ops", Owner: "nobody", Sharing: "app"})
+	g.link(map[string][]string{
+		search: {"`auth` | lookup geo_ip clientip"},
+	}, map[string][]objectRef{
+		autoLookup: {{Type: ObjectLookup, Name: "assets"}},
+	})
+
+	report := g.unusedObjects(map[string]bool{"nobody": true}, "")
+
+	// assets is used by the automatic lookup only, the unreferenced eventtype and lookup say they may still be in use
+	wantUnreferenced := []ReportedObject{
+		{Type: ObjectEventtype, Name: "vpn", App: "ops", Owner: "dave", Note: unreferencedNotes[ObjectEventtype]},
+		{Type: ObjectLookup, Name: "old_assets", App: "ops", Owner: "nobody", Note: unreferencedNotes[ObjectLookup]},
+		{Type: ObjectMacro, Name: "auth", App: "ops", Owner: "nobody"},
+	}
+	if !reflect.DeepEqual(report.Unreferenced, wantUnreferenced) {
+		t.Errorf("Unreferenced = %+v, want %+v", report.Unreferenced, wantUnreferenced)
+	}
+	// the missing geo_ip lookup is neither unused nor orphaned
+	wantOrphaned := []ReportedObject{{Type: ObjectEventtype, Name: "vpn", App: "ops", Owner: "dave"}}
+	if !reflect.DeepEqual(report.Orphaned, wantOrphaned) {
+		t.Errorf("Orphaned = %+v, want %+v", report.Orphaned, wantOrphaned)
+	}
+
+	if got := g.unusedObjects(map[string]bool{"nobody": true}, "sec"); len(got.Unreferenced) != 0 {
+		t.Errorf("Unreferenced in sec = %+v, want none", got.Unreferenced)
+	}
+}
@@ -1,5 +1,18 @@
 package splunk
 
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
 // getString safely gets a string from a map
 func getString(m map[string]interface{}, key string) string {
 	if v, ok := m[key]; ok {
@@ -9,3 +22,261 @@ func getString(m map[string]interface{}, key string) string {
 	}
 	return ""
 }
+
+// exportSearch runs SPL through /services/search/jobs/export and returns the final (non-preview) result rows
+func (c *Client) exportSearch(ctx context.Context, spl string) ([]map[string]interface{}, error) {
+	endpoint := fmt.Sprintf("%s/services/search/jobs/export", c.BaseURL)
+	form := url.Values{}
+	form.Set("search", spl)
+	form.Set("output_mode", "json")
+
+	req, err := http.NewRequestWithContext(ctx, "POST", endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AuthToken))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	// Parse streaming JSON results, skipping preview rows of reporting searches
+	dec := json.NewDecoder(resp.Body)
+	var rows []map[string]interface{}
+	for {
+		var row struct {
+			Preview bool                   `json:"preview"`
+			Result  map[string]interface{} `json:"result"`
+		}
+		if err := dec.Decode(&row); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to decode response: %w", err)
+		}
+		if row.Preview || row.Result == nil {
+			continue
+		}
+		rows = append(rows, row.Result)
+	}
+
+	return rows, nil
+}
+
+// truncate shortens s to at most n runes, marking the cut with "..."
+func truncate(s string, n int) string {
+	if utf8.RuneCountInString(s) <= n {
+		return s
+	}
+	return string([]rune(s)[:n]) + "..."
+}
+
+var splQuoteEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// QuoteSPL quotes v as an SPL string literal, so a value can't close the quotes and add search terms
+func QuoteSPL(v string) string {
+	return `"` + splQuoteEscaper.Replace(v) + `"`
+}
+
+// getStrings safely gets a multivalue field from a map, accepting both a single string and a list
+func getStrings(m map[string]interface{}, key string) []string {
+	switch v := m[key].(type) {
+	case string:
+		if v == "" {
+			return nil
+		}
+		return []string{v}
+	case []interface{}:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+// restEntry is a generic entry of a Splunk REST list endpoint with untyped content
+type restEntry struct {
+	Name    string                 `json:"name"`
+	Content map[string]interface{} `json:"content"`
+	ACL     restACL                `json:"acl"`
+}
+
+// getAllRESTEntries retrieves every entry of a REST list endpoint (e.g. /servicesNS/-/-/saved/eventtypes) in one request
+func (c *Client) getAllRESTEntries(ctx context.Context, path string) ([]restEntry, error) {
+	entries, _, err := c.getRESTEntries(ctx, path, url.Values{"count": {"0"}})
+	return entries, err
+}
+
+// getRESTEntries retrieves one page of a REST list endpoint with the given query parameters (count, offset, search, ...)
+// and returns the entries together with the total reported by Splunk paging
+func (c *Client) getRESTEntries(ctx context.Context, path string, params url.Values) ([]restEntry, int, error) {
+	query := url.Values{}
+	for k, v := range params {
+		query[k] = v
+	}
+	query.Set("output_mode", "json")
+	endpoint := fmt.Sprintf("%s%s?%s", c.BaseURL, path, query.Encode())
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AuthToken))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, 0, errNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Entry  []restEntry `json:"entry"`
+		Paging struct {
+			Total int `json:"total"`
+		} `json:"paging"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return result.Entry, result.Paging.Total, nil
+}
+
+// errNotFound is returned by getRESTEntries when the endpoint or the named entry does not exist
+var errNotFound = errors.New("not found")
+
+// getBool safely gets a boolean from a map; Splunk returns booleans as true/false, 0/1 or "0"/"1" depending on the endpoint
+func getBool(m map[string]interface{}, key string) bool {
+	switch v := m[key].(type) {
+	case bool:
+		return v
+	case float64:
+		return v != 0
+	case string:
+		return v == "1" || strings.EqualFold(v, "true")
+	}
+	return false
+}
+
+// SelectFields re-encodes items as JSON objects that only keep the given JSON field names.
+// It lets list tools return a caller-chosen subset of a detail type.
+func SelectFields(items interface{}, fields []string) ([]map[string]interface{}, error) {
+	data, err := json.Marshal(items)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal(data, &rows); err != nil {
+		return nil, err
+	}
+	for i, row := range rows {
+		selected := map[string]interface{}{}
+		for _, f := range fields {
+			if v, ok := row[f]; ok {
+				selected[f] = v
+			}
+		}
+		rows[i] = selected
+	}
+	return rows, nil
+}
+
+// getInt safely gets an integer from a search result, where numbers are returned as strings
+func getInt(m map[string]interface{}, key string) int {
+	switch v := m[key].(type) {
+	case float64:
+		return int(v)
+	case string:
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return int(getFloat(m, key))
+		}
+		return n
+	}
+	return 0
+}
+
+// getFloat safely gets a float from a search result, where numbers are returned as strings
+func getFloat(m map[string]interface{}, key string) float64 {
+	switch v := m[key].(type) {
+	case float64:
+		return v
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0
+		}
+		return f
+	}
+	return 0
+}
+
+// doForm sends a form-encoded write request (POST or DELETE) to a REST path and returns Splunk's error message on failure.
+// When out is not nil, the JSON response is decoded into it.
+func (c *Client) doForm(ctx context.Context, method, path string, form url.Values, out interface{}) error {
+	if form == nil {
+		form = url.Values{}
+	}
+	form.Set("output_mode", "json")
+	endpoint := fmt.Sprintf("%s%s", c.BaseURL, path)
+
+	var body io.Reader
+	if method == "DELETE" {
+		endpoint += "?" + form.Encode()
+	} else {
+		body = strings.NewReader(form.Encode())
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.AuthToken))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var result struct {
+			Messages []struct {
+				Text string `json:"text"`
+			} `json:"messages"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&result); err == nil && len(result.Messages) > 0 {
+			return fmt.Errorf("unexpected status code: %d: %s", resp.StatusCode, result.Messages[0].Text)
+		}
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("failed to decode response: %w", err)
+		}
+	}
+	return nil
+}